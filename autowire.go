@@ -62,3 +62,8 @@ func Get[T any](c Container) (value T, err error) {
 func Resolve[T any](c Container) (DependencyGraph, error) {
 	return c.Resolve(typeFor[T]())
 }
+
+// UnusedProviders returns providers within a container which are not required to build the specified type
+func UnusedProviders[T any](c Container) []Provider {
+	return c.UnusedProviders(typeFor[T]())
+}
@@ -14,7 +14,8 @@ func TestBuild_Failure(t *testing.T) {
 		_, err = Build[Service1](c)
 		assert.ErrorIs(t, err, ErrNotFound)
 		assert.Contains(t, err.Error(),
-			"ErrNotFound: provider not found for type 'autowire.Service3'")
+			"ErrNotFound: provider not found for type 'autowire.Service3', "+
+				"required by provider 'github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3 (data_test.go:")
 	})
 
 	t.Run("Provider not found (primitive type)", func(t *testing.T) {
@@ -44,7 +45,9 @@ func TestBuild_Failure(t *testing.T) {
 		assert.Nil(t, err)
 		_, err = Build[Service1](c)
 		assert.ErrorIs(t, err, errTest1)
-		assert.Contains(t, err.Error(), "errTest1")
+		assert.Contains(t, err.Error(), "errTest1: provider 'github.com/tiendc/autowire.NewSrv1_Fail_With_Err "+
+			"(data_test.go:")
+		assert.Contains(t, err.Error(), "failed to build type 'autowire.Service1'")
 	})
 
 	t.Run("Circular dependency", func(t *testing.T) {
@@ -78,6 +81,70 @@ func TestBuild_Failure(t *testing.T) {
 	})
 }
 
+func TestBuild_Context(t *testing.T) {
+	t.Run("Context provider is not allowed", func(t *testing.T) {
+		_, err := NewContainer([]any{NewSrv1_OK, func() context.Context { return context.Background() }})
+		assert.ErrorIs(t, err, ErrProviderInvalid)
+		assert.Contains(t, err.Error(),
+			"ErrProviderInvalid: type 'context.Context' must not be provided, it is passed via BuildWithCtx")
+	})
+
+	t.Run("Context passed via ProviderOverwrite", func(t *testing.T) {
+		ctx := context.Background()
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Ctx})
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c, ProviderOverwrite(ctx))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{ctx}, s1.InitArgs())
+	})
+
+	t.Run("Argument of an interface type implementing context.Context", func(t *testing.T) {
+		type customCtx interface {
+			context.Context
+		}
+		type customCtxWithMethod interface {
+			context.Context
+			Method()
+		}
+		c, err := NewContainer([]any{
+			func(ctx customCtx) Service1 { return &service1{serviceBase{initArgs: []any{ctx}}} },
+			func(ctx customCtxWithMethod) Service2 { return &service2{} },
+		})
+		assert.Nil(t, err)
+		ctx := context.Background()
+		s1, err := BuildWithCtx[Service1](ctx, c)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{ctx}, s1.InitArgs())
+
+		// The context doesn't implement the interface, the type is looked up as a regular type
+		_, err = BuildWithCtx[Service2](ctx, c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "provider not found for type 'autowire.customCtxWithMethod'")
+	})
+
+	t.Run("Interface embedding context.Context can be provided", func(t *testing.T) {
+		type customCtx interface {
+			context.Context
+			Method()
+		}
+		provided := customCtxImpl{Context: context.Background()}
+		c, err := NewContainer([]any{
+			func() customCtx { return provided },
+			func(ctx customCtx) Service1 { return &service1{serviceBase{initArgs: []any{ctx}}} },
+		})
+		assert.Nil(t, err)
+		s1, err := BuildWithCtx[Service1](context.Background(), c)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{provided}, s1.InitArgs())
+	})
+}
+
+type customCtxImpl struct {
+	context.Context
+}
+
+func (customCtxImpl) Method() {}
+
 func TestBuild_Success(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK_With_Need_Srv4_Srv5,
@@ -0,0 +1,126 @@
+// Package autowiretest provides helpers for using autowire containers in tests.
+//
+//	func TestServiceA(t *testing.T) {
+//		c := autowiretest.New(t, NewServiceA, NewRepoX, NewRepoY)
+//		svc := autowiretest.MustBuild[ServiceA](t, c, autowire.ProviderOverwrite[RepoX](fakeRepoX))
+//		...
+//	}
+package autowiretest
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tiendc/autowire"
+)
+
+// containerPaths holds the build path recorder of containers created by New
+var containerPaths sync.Map
+
+// New creates a container with the providers and fails the test on error.
+// The container is closed when the test and all its subtests complete.
+func New(t testing.TB, providers ...any) autowire.Container {
+	t.Helper()
+	return NewWithConfig(t, providers)
+}
+
+// NewWithConfig creates a container with the providers and config options, and fails the test on error.
+// The container is closed when the test and all its subtests complete.
+func NewWithConfig(t testing.TB, providers []any, opts ...autowire.ContainerConfigOption) autowire.Container {
+	t.Helper()
+	recorder := &pathRecorder{}
+	c, err := autowire.NewContainer(providers, append(opts, autowire.WithObserver(recorder))...)
+	if err != nil {
+		t.Fatalf("autowiretest: failed to create container: %v", err)
+		return nil
+	}
+	containerPaths.Store(c, recorder)
+	t.Cleanup(func() {
+		containerPaths.Delete(c)
+		if err := c.Close(); err != nil {
+			t.Errorf("autowiretest: failed to close container: %v", err)
+		}
+	})
+	return c
+}
+
+// MustBuild builds an object of type T and fails the test on error. Objects built with overwrites or stubs
+// are not cached in the container, so they don't affect other builds.
+// For containers created by New, the failure message shows the dependency path of the failed type.
+func MustBuild[T any](t testing.TB, c autowire.Container, opts ...autowire.ContextOption) T {
+	t.Helper()
+	recorder := lookupRecorder(c)
+	if recorder != nil {
+		recorder.reset()
+	}
+	value, err := autowire.Build[T](c, opts...)
+	if err != nil {
+		msg := err.Error()
+		if path := recorder.failedPath(); path != "" {
+			msg += "\ndependency path: " + path
+		}
+		t.Fatalf("autowiretest: failed to build type '%v': %s", reflect.TypeOf((*T)(nil)).Elem(), msg)
+	}
+	return value
+}
+
+func lookupRecorder(c autowire.Container) *pathRecorder {
+	if recorder, ok := containerPaths.Load(c); ok {
+		return recorder.(*pathRecorder) //nolint:forcetypeassert
+	}
+	return nil
+}
+
+// pathRecorder an observer recording the dependency path of the first failed type in a build
+type pathRecorder struct {
+	autowire.NoopObserver
+	mu     sync.Mutex
+	stack  []reflect.Type
+	failed []reflect.Type
+}
+
+func (r *pathRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stack = nil
+	r.failed = nil
+}
+
+// OnBuildStart implementation of autowire.Observer interface
+func (r *pathRecorder) OnBuildStart(targetType reflect.Type, _ autowire.Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stack = append(r.stack, targetType)
+}
+
+// OnBuildEnd implementation of autowire.Observer interface
+func (r *pathRecorder) OnBuildEnd(targetType reflect.Type, _ autowire.Provider, _ time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil && r.failed == nil {
+		r.failed = append([]reflect.Type{}, r.stack...)
+	}
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		if r.stack[i] == targetType {
+			r.stack = append(r.stack[:i], r.stack[i+1:]...)
+			break
+		}
+	}
+}
+
+// failedPath returns the dependency path of the first failed type, such as "A -> B -> C"
+func (r *pathRecorder) failedPath() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.failed))
+	for _, typ := range r.failed {
+		names = append(names, typ.String())
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,151 @@
+package autowiretest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/autowire"
+)
+
+var errTest = errors.New("errTest")
+
+type ServiceA struct {
+	RepoX RepoX
+}
+
+type RepoX interface {
+	Name() string
+}
+
+type repoX struct {
+	name   string
+	closed bool
+}
+
+func (r *repoX) Name() string { return r.name }
+
+func (r *repoX) Close() error {
+	r.closed = true
+	return nil
+}
+
+type RepoY struct{}
+
+func NewServiceA(x RepoX) *ServiceA {
+	return &ServiceA{RepoX: x}
+}
+
+func NewRepoX(*RepoY) RepoX {
+	return &repoX{name: "real"}
+}
+
+func NewRepoYFail() (*RepoY, error) {
+	return nil, errTest
+}
+
+func NewRepoY() *RepoY {
+	return &RepoY{}
+}
+
+// fakeTB records failures instead of stopping the test
+type fakeTB struct {
+	testing.TB
+	failures []string
+	cleanups []func()
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Fatalf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Cleanup(fn func()) {
+	tb.cleanups = append(tb.cleanups, fn)
+}
+
+func (tb *fakeTB) runCleanups() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := New(tb, NewServiceA, NewRepoX, NewRepoY)
+		x, err := autowire.Build[RepoX](c)
+		assert.Nil(t, err)
+
+		tb.runCleanups()
+		assert.True(t, x.(*repoX).closed)
+		assert.Equal(t, 0, len(tb.failures))
+		_, exist := containerPaths.Load(c)
+		assert.False(t, exist)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := New(tb, NewServiceA, NewServiceA)
+		assert.Nil(t, c)
+		assert.Equal(t, 1, len(tb.failures))
+		assert.Contains(t, tb.failures[0], "autowiretest: failed to create container")
+		assert.Contains(t, tb.failures[0], autowire.ErrProviderDuplicated.Error())
+	})
+
+	t.Run("With config", func(t *testing.T) {
+		c := NewWithConfig(t, []any{NewRepoY}, autowire.SetSharedMode(false))
+		assert.False(t, c.SharedMode())
+	})
+}
+
+func TestMustBuild(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c := New(t, NewServiceA, NewRepoX, NewRepoY)
+		fake := &repoX{name: "fake"}
+		a := MustBuild[*ServiceA](t, c, autowire.ProviderOverwrite[RepoX](fake))
+		assert.Equal(t, fake, a.RepoX)
+
+		// Objects built with overwrites are not cached
+		assert.Equal(t, 0, len(c.Objects()))
+		a = MustBuild[*ServiceA](t, c)
+		assert.Equal(t, "real", a.RepoX.Name())
+		assert.Equal(t, 3, len(c.Objects()))
+	})
+
+	t.Run("Success with stubs", func(t *testing.T) {
+		c := New(t, NewServiceA)
+		fake := &repoX{name: "fake"}
+		a := MustBuild[*ServiceA](t, c, autowire.StubMissingInterfaces(autowire.Stub[RepoX](fake)))
+		assert.Equal(t, fake, a.RepoX)
+
+		// Objects built with stubs are not cached
+		assert.Equal(t, 0, len(c.Objects()))
+	})
+
+	t.Run("Failure with dependency path", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := New(tb, NewServiceA, NewRepoX, NewRepoYFail)
+		MustBuild[*ServiceA](tb, c)
+		assert.Equal(t, 1, len(tb.failures))
+		assert.Contains(t, tb.failures[0], "autowiretest: failed to build type '*autowiretest.ServiceA': errTest")
+		assert.Contains(t, tb.failures[0],
+			"dependency path: *autowiretest.ServiceA -> autowiretest.RepoX -> *autowiretest.RepoY")
+	})
+
+	t.Run("Failure without recorder", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := autowire.MustNewContainer([]any{NewServiceA})
+		MustBuild[*ServiceA](tb, c)
+		assert.Equal(t, 1, len(tb.failures))
+		assert.Contains(t, tb.failures[0], autowire.ErrNotFound.Error())
+		assert.NotContains(t, tb.failures[0], "dependency path")
+	})
+}
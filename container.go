@@ -3,7 +3,9 @@ package autowire
 import (
 	"context"
 	"fmt"
+	"io"
 	"reflect"
+	"sync"
 )
 
 // Container is a storage for storing every object created by the providers of the container.
@@ -21,9 +23,53 @@ type Container interface {
 	// setSharedMode sets shared mode
 	setSharedMode(bool)
 
-	// ProviderSet gets provider set within the container
+	// setSealedMode sets sealed mode
+	setSealedMode(bool)
+
+	// setValidationMode sets validation mode
+	setValidationMode(bool)
+
+	// setParallelWorkers sets number of workers used in parallel mode
+	setParallelWorkers(int)
+
+	// addObserver adds an observer of build events
+	addObserver(Observer)
+
+	// setLogger sets the logger of diagnostic events, it is also added as an observer
+	setLogger(diagnosticLogger)
+
+	// addValidator adds a validator which is called after the container is created
+	addValidator(func(Container) error)
+
+	// ProviderSet gets provider set within the container.
+	// The set is replaced when providers are added via Provide, changed via Replace or Remove, or a snapshot
+	// is restored. Sets returned before are detached from the container then, overwriting providers on them
+	// doesn't affect the container, so call ProviderSet again after these operations.
 	ProviderSet() ProviderSet
 
+	// Provide adds providers to the container after it is created. Providers are accepted in the same
+	// forms as NewContainer, and duplicated providers of existing types are rejected. In sealed mode,
+	// ErrContainerSealed is returned after the first build of the container.
+	//
+	// Every method of the container can be called concurrently. Builds in progress keep using the
+	// providers they started with. In shared mode, when concurrent builds need an object of the same type,
+	// one of them calls the provider, and the others wait for it and use the same object.
+	Provide(providers ...any) error
+
+	// Replace replaces the providers of the target types of the specified provider, which can be
+	// a function, a struct pointer, or an object of type `Provider`. Every target type must have a provider.
+	// Cached objects of the target types and of every type depending on them are evicted.
+	// Evicted objects are not closed, they may still be in use. Objects of builds in progress are not cached,
+	// as they may be built with the replaced providers. Providers of the target types overwritten via
+	// ProviderSet().Overwrite are replaced too.
+	Replace(provider any) error
+
+	// Remove removes the provider of the type. Cached objects of the type and of every type depending
+	// on it are evicted, and objects of builds in progress are not cached. In validation mode, ErrNotFound
+	// is returned if other providers depend on the type. A provider overwritten via ProviderSet().Overwrite
+	// is removed too.
+	Remove(targetType reflect.Type) error
+
 	// Get gets a value stored in the container for the specified type.
 	// If not found, returns ErrNotFound.
 	Get(targetType reflect.Type) (reflect.Value, error)
@@ -37,6 +83,52 @@ type Container interface {
 
 	// Resolve builds dependency graph for the specified type
 	Resolve(targetType reflect.Type) (DependencyGraph, error)
+
+	// WriteDOT writes dependency graph of the specified types in Graphviz DOT format.
+	// When no type is specified, the graph of every type provided by the container is written.
+	// Unlike Resolve, missing and circular dependencies are highlighted in the graph instead
+	// of causing an error.
+	WriteDOT(w io.Writer, targetTypes ...reflect.Type) error
+
+	// Graph builds dependency graph of every provider in the container.
+	// Unlike Resolve, each type appears once in the graph and missing types don't cause an error.
+	Graph() *Graph
+
+	// ExportGraph exports dependency graph of the specified types in a serializable form which
+	// can be rendered as DOT, Mermaid, or JSON. When no type is specified, the graph of every type
+	// provided by the container is exported. Missing and circular dependencies are marked in the graph.
+	ExportGraph(targetTypes ...reflect.Type) GraphData
+
+	// UnusedProviders returns providers of the container which are not required to build any of
+	// the specified root types, sorted by provider name.
+	UnusedProviders(roots ...reflect.Type) []Provider
+
+	// Providers returns descriptors of every provider of the container, sorted by provider name
+	Providers() []ProviderDescriptor
+
+	// Objects returns descriptors of every object cached in the container in the order of building
+	Objects() []ObjectDescriptor
+
+	// Close closes every object cached in the container which implements io.Closer in the reverse
+	// order of building, so objects are closed before their dependencies. The cache is cleared after that.
+	// Closing continues on failure, and the first error is returned. Objects of builds in progress are not
+	// cached, they are owned by the callers of the builds.
+	Close() error
+
+	// Snapshot saves the current state of the container, which are cached objects and providers.
+	Snapshot() *Snapshot
+
+	// Restore reverts the container to the state saved by Snapshot. Objects cached after the snapshot
+	// are removed from the cache and closed if they implement io.Closer. Objects removed from the cache
+	// after the snapshot, such as by Close, are not restored. Providers overwritten on the container's
+	// provider set after the snapshot are reverted, and the provider set returned by ProviderSet before
+	// restoring is detached from the container.
+	Restore(*Snapshot) error
+
+	// Clone creates an independent container with the same providers and configuration.
+	// Cached objects are not copied unless CloneObjects is specified. Overwriting providers or
+	// building objects in the clone doesn't affect the original container and vice versa.
+	Clone(opts ...CloneOption) (Container, error)
 }
 
 // ContainerConfigOption config option setter used when create a container
@@ -49,11 +141,38 @@ func SetSharedMode(flag bool) ContainerConfigOption {
 	}
 }
 
+// SetSealedMode config option for setting `sealedMode` for a container.
+// In sealed mode, providers can't be added via Provide after the first build of the container.
+func SetSealedMode(flag bool) ContainerConfigOption {
+	return func(c Container) {
+		c.setSealedMode(flag)
+	}
+}
+
 // container an implementation of Container interface
 type container struct {
-	sharedMode  bool
+	sharedMode      bool
+	sealedMode      bool
+	validationMode  bool
+	parallelWorkers int
+
+	// mu guards providerSet and objects. Once assigned, the cache and the base providers of the set
+	// are never modified, they are replaced by modified copies instead.
+	// So builds can use them without holding the lock.
+	mu          sync.RWMutex
 	providerSet ProviderSet
-	objectMap   map[reflect.Type]reflect.Value
+	objects     *objectCache
+	// generation is increased when the providers or the cache change other than by committing a build.
+	// Objects of builds started in an earlier generation are not cached. It is guarded by mu.
+	generation uint64
+	// built is set to 1 when the container starts the first build
+	built int32
+	// flights shares the construction of objects between concurrent builds
+	flights flightGroup
+
+	validators []func(Container) error
+	observers  []Observer
+	logger     diagnosticLogger
 }
 
 // SharedMode implementation of Container interface
@@ -66,17 +185,50 @@ func (c *container) setSharedMode(flag bool) {
 	c.sharedMode = flag
 }
 
+// setSealedMode implementation of Container interface
+func (c *container) setSealedMode(flag bool) {
+	c.sealedMode = flag
+}
+
+// setParallelWorkers implementation of Container interface
+func (c *container) setParallelWorkers(workers int) {
+	c.parallelWorkers = workers
+}
+
+// addObserver implementation of Container interface
+func (c *container) addObserver(observer Observer) {
+	c.observers = append(c.observers, observer)
+}
+
+// setLogger implementation of Container interface
+func (c *container) setLogger(logger diagnosticLogger) {
+	c.logger = logger
+	c.observers = append(c.observers, logger)
+}
+
+// addValidator implementation of Container interface
+func (c *container) addValidator(validator func(Container) error) {
+	c.validators = append(c.validators, validator)
+}
+
 // ProviderSet implementation of Container interface
 func (c *container) ProviderSet() ProviderSet {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.providerSet
 }
 
+// cache returns the cached objects
+func (c *container) cache() *objectCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.objects
+}
+
 // Get implementation of Container interface
 func (c *container) Get(targetType reflect.Type) (value reflect.Value, err error) {
-	if c.objectMap != nil {
-		if value, exist := c.objectMap[targetType]; exist {
-			return value, nil
-		}
+	if obj, exist := c.cache().get(targetType); exist {
+		return obj.value, nil
 	}
 	return value, fmt.Errorf("%w: object not found for type '%v'", ErrNotFound, targetType)
 }
@@ -99,11 +251,17 @@ func NewContainer(providers []any, opts ...ContainerConfigOption) (Container, er
 	c := &container{
 		sharedMode:  true,
 		providerSet: providerSet,
-		objectMap:   map[reflect.Type]reflect.Value{},
+		objects:     newObjectCache(nil),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.logRegistration(providerSet.GetAll())
+	for _, validator := range c.validators {
+		if err = validator(c); err != nil {
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
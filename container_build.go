@@ -3,36 +3,116 @@ package autowire
 import (
 	"context"
 	"reflect"
+	"sync/atomic"
 )
 
 // Build implementation of Container interface
 func (c *container) Build(targetType reflect.Type, opts ...ContextOption) (value reflect.Value, err error) {
-	provider, err := c.providerSet.GetFor(targetType)
-	if err != nil {
+	atomic.StoreInt32(&c.built, 1)
+	ctx := c.newContext(opts)
+	if ctx.optionErr != nil {
+		return value, ctx.optionErr
+	}
+	if value, err = c.build(ctx, targetType); err != nil {
+		// Objects created within the failed build are not cached, nothing else can close them unless
+		// they are used by concurrent builds. The error of the build is more relevant than errors of
+		// closing them.
+		inUse := ctx.leaveFlights(false)
+		objects := make(map[reflect.Type]builtObject, len(ctx.builtObjects))
+		for typ, obj := range ctx.builtObjects {
+			if _, exist := inUse[typ]; !exist {
+				objects[typ] = obj
+			}
+		}
+		_ = closeObjects(describeObjects(objects))
 		return value, err
 	}
+	c.commitObjects(ctx)
+	ctx.leaveFlights(true)
+	return value, nil
+}
 
+// newContext creates a context for a build with the providers and cached objects of the container
+func (c *container) newContext(opts []ContextOption) *Context {
+	// The cache must match the providers, so both are taken at once
+	c.mu.RLock()
+	providerSet, objects, generation := c.providerSet, c.objects, c.generation
+	c.mu.RUnlock()
 	ctx := &Context{
-		sharedMode:     c.sharedMode,
-		providerSet:    c.providerSet.shallowClone(),
-		objectMap:      c.objectMap,
-		resolvingTypes: make(map[reflect.Type]struct{}, 10), //nolint:gomnd
+		sharedMode:      c.sharedMode,
+		parallelWorkers: c.parallelWorkers,
+		providerSet:     providerSet.shallowClone(),
+		cachedObjects:   objects,
+		generation:      generation,
+		flights:         &c.flights,
+		usedFlights:     map[reflect.Type]*flight{},
+		observers:       c.observers,
+		logger:          c.logger,
+		resolvingTypes:  make(map[reflect.Type]struct{}, 10), //nolint:gomnd
 	}
 	for _, opt := range opts {
 		opt(ctx)
 	}
+	if ctx.optionErr == nil {
+		ctx.resolveAffectedTypes()
+	}
+	return ctx
+}
 
-	value, err = provider.Build(ctx, targetType)
+func (c *container) build(ctx *Context, targetType reflect.Type) (reflect.Value, error) {
+	// Look up the provider after applying options, so the target type itself can be overwritten
+	provider, err := ctx.providerSet.GetFor(targetType)
 	if err != nil {
-		return value, err
+		return reflect.Value{}, err
+	}
+	if ctx.sharedMode && ctx.parallelWorkers > 1 {
+		return c.buildParallel(ctx, provider, targetType)
 	}
+	return provider.Build(ctx, targetType)
+}
 
-	return value, nil
+// commitObjects adds objects created within a successful build to the cache. Objects of types cached by
+// concurrent builds are the same objects, they are taken from the builds which created them. When the
+// providers or the cache have changed since the build started, such as by Close, nothing is added as
+// the objects may be outdated, they are owned by the caller of the build.
+func (c *container) commitObjects(ctx *Context) {
+	if len(ctx.builtObjects) == 0 {
+		return
+	}
+	objects := make(map[reflect.Type]builtObject, len(ctx.builtObjects))
+	for typ, obj := range ctx.builtObjects {
+		// Objects built with overwritten or stubbed dependencies are local to the build
+		if _, affected := ctx.affectedTypes[typ]; affected {
+			continue
+		}
+		objects[typ] = obj
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != ctx.generation {
+		return
+	}
+	for typ := range objects {
+		if _, exist := c.objects.get(typ); exist {
+			delete(objects, typ)
+		}
+	}
+	if len(objects) > 0 {
+		c.objects = c.objects.with(objects)
+	}
 }
 
 // BuildWithCtx implementation of Container interface
 func (c *container) BuildWithCtx(ctx context.Context, targetType reflect.Type, opts ...ContextOption) (
 	value reflect.Value, err error,
 ) {
-	return c.Build(targetType, append(opts, ProviderOverwrite(ctx))...)
+	return c.Build(targetType, append(opts, withContext(ctx))...)
+}
+
+// withContext sets context.Context object for the current build
+func withContext(goCtx context.Context) ContextOption {
+	return func(ctx *Context) {
+		ctx.context = goCtx
+	}
 }
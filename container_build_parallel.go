@@ -0,0 +1,193 @@
+package autowire
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// SetParallelMode config option for building independent dependencies concurrently with
+// the specified number of workers. Non-positive number means the number of CPUs.
+//
+// Parallel mode only takes effect in shared mode, so every object is still created only once.
+// When multiple providers fail, the reported error is the same as the one of a sequential build.
+// Note that a child context derived by a provider (see DeriveContext) is not passed to its
+// dependencies in this mode, as they are built before the provider.
+func SetParallelMode(workers int) ContainerConfigOption {
+	return func(c Container) {
+		c.setParallelWorkers(parallelWorkers(workers))
+	}
+}
+
+// ParallelMode enables parallel mode for the current build (see SetParallelMode)
+func ParallelMode(workers int) ContextOption {
+	return func(ctx *Context) {
+		ctx.parallelWorkers = parallelWorkers(workers)
+	}
+}
+
+func parallelWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.NumCPU()
+	}
+	return workers
+}
+
+// buildPlan dependency DAG of a type. It contains only types which need to be built.
+type buildPlan struct {
+	// order contains types in the order of a sequential build, dependencies come first
+	order      []reflect.Type
+	index      map[reflect.Type]int
+	providers  map[reflect.Type]Provider
+	numDeps    map[reflect.Type]int
+	dependents map[reflect.Type][]reflect.Type
+	visiting   map[reflect.Type]struct{}
+}
+
+func newBuildPlan(ctx *Context, targetType reflect.Type) (*buildPlan, error) {
+	plan := &buildPlan{
+		index:      map[reflect.Type]int{},
+		providers:  map[reflect.Type]Provider{},
+		numDeps:    map[reflect.Type]int{},
+		dependents: map[reflect.Type][]reflect.Type{},
+		visiting:   map[reflect.Type]struct{}{},
+	}
+	if err := plan.visit(ctx, targetType); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (plan *buildPlan) visit(ctx *Context, typ reflect.Type) error {
+	if _, exist := plan.index[typ]; exist {
+		return nil
+	}
+	if _, exist := ctx.getObject(typ); exist {
+		return nil
+	}
+	if _, exist := plan.visiting[typ]; exist {
+		return fmt.Errorf("%w: circular dependency detected at type '%v'", ErrCircularDependency, typ)
+	}
+	provider, err := ctx.providerSet.GetFor(typ)
+	if err != nil {
+		return err
+	}
+
+	plan.visiting[typ] = struct{}{}
+	for _, dType := range provider.DependentTypes() {
+		if isContextType(dType) {
+			continue
+		}
+		if err = plan.visit(ctx, dType); err != nil {
+			return err
+		}
+		if _, exist := plan.index[dType]; exist {
+			plan.numDeps[typ]++
+			plan.dependents[dType] = append(plan.dependents[dType], typ)
+		}
+	}
+	delete(plan.visiting, typ)
+
+	plan.index[typ] = len(plan.order)
+	plan.order = append(plan.order, typ)
+	plan.providers[typ] = provider
+	return nil
+}
+
+// buildResult result of building a type of a plan
+type buildResult struct {
+	typ      reflect.Type
+	err      error
+	panicVal any
+}
+
+// buildParallel builds the target type with constructing independent dependencies concurrently.
+// Every type in the plan is built by a worker after all of its dependencies are built. Types
+// following a failed type in the sequential order are skipped, so the earliest failure in
+// that order is always reported regardless of timing.
+func (c *container) buildParallel(ctx *Context, provider Provider, targetType reflect.Type) (reflect.Value, error) {
+	plan, err := newBuildPlan(ctx, targetType)
+	if err != nil {
+		// Let the sequential build report the error with its full details
+		return provider.Build(ctx, targetType)
+	}
+
+	// Task contexts are copies of this context, they must share the same map and mutex
+	if ctx.builtObjects == nil {
+		ctx.builtObjects = map[reflect.Type]builtObject{}
+	}
+	ctx.builtObjectsMu = &sync.Mutex{}
+	defer func() {
+		ctx.builtObjectsMu = nil
+	}()
+
+	var ready []reflect.Type
+	for _, typ := range plan.order {
+		if plan.numDeps[typ] == 0 {
+			ready = append(ready, typ)
+		}
+	}
+
+	results := make(chan buildResult, len(plan.order))
+	failedIndex := len(plan.order)
+	var failedErr error
+	var panicVal any
+	running := 0
+	for {
+		for running < ctx.parallelWorkers && len(ready) > 0 {
+			typ := ready[0]
+			ready = ready[1:]
+			if plan.index[typ] > failedIndex {
+				continue
+			}
+			running++
+			go c.buildTask(ctx.taskContext(), plan.providers[typ], typ, results)
+		}
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+		if result.panicVal != nil && panicVal == nil {
+			panicVal = result.panicVal
+		}
+		if result.err != nil || result.panicVal != nil {
+			if idx := plan.index[result.typ]; idx < failedIndex {
+				failedIndex, failedErr = idx, result.err
+			}
+			continue
+		}
+		for _, dependent := range plan.dependents[result.typ] {
+			plan.numDeps[dependent]--
+			if plan.numDeps[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool {
+			return plan.index[ready[i]] < plan.index[ready[j]]
+		})
+	}
+
+	if panicVal != nil {
+		panic(panicVal)
+	}
+	if failedErr != nil {
+		return reflect.Value{}, failedErr
+	}
+	// The target object is already built, this returns it from the cache
+	return provider.Build(ctx, targetType)
+}
+
+func (c *container) buildTask(ctx *Context, provider Provider, typ reflect.Type, results chan<- buildResult) {
+	result := buildResult{typ: typ}
+	defer func() {
+		if r := recover(); r != nil {
+			result.panicVal = r
+		}
+		results <- result
+	}()
+	_, result.err = provider.Build(ctx, typ)
+}
@@ -0,0 +1,140 @@
+package autowire
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerBuildParallel(t *testing.T) {
+	// S1 -> (S2, S3), S2 -> (S4, S5), S3 -> S4
+	type counter struct {
+		mu      sync.Mutex
+		calls   map[string]int
+		running int32
+		maxConc int32
+	}
+	newProviders := func(cnt *counter, delay time.Duration, errs map[string]error) []any {
+		track := func(name string) error {
+			n := atomic.AddInt32(&cnt.running, 1)
+			defer atomic.AddInt32(&cnt.running, -1)
+			for {
+				m := atomic.LoadInt32(&cnt.maxConc)
+				if n <= m || atomic.CompareAndSwapInt32(&cnt.maxConc, m, n) {
+					break
+				}
+			}
+			cnt.mu.Lock()
+			cnt.calls[name]++
+			cnt.mu.Unlock()
+			time.Sleep(delay)
+			return errs[name]
+		}
+		return []any{
+			func(s2 Service2, s3 Service3) (Service1, error) {
+				return &service1{serviceBase{initArgs: []any{s2, s3}}}, track("s1")
+			},
+			func(s4 Service4, s5 Service5) (Service2, error) {
+				return &service2{serviceBase{initArgs: []any{s4, s5}}}, track("s2")
+			},
+			func(s4 Service4) (Service3, error) {
+				return &service3{serviceBase{initArgs: []any{s4}}}, track("s3")
+			},
+			func() (Service4, error) { return &service4{}, track("s4") },
+			func() (Service5, error) { return &service5{}, track("s5") },
+		}
+	}
+
+	t.Run("Independent dependencies are built concurrently", func(t *testing.T) {
+		cnt := &counter{calls: map[string]int{}}
+		c, err := NewContainer(newProviders(cnt, 20*time.Millisecond, nil), SetParallelMode(4))
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"s1": 1, "s2": 1, "s3": 1, "s4": 1, "s5": 1}, cnt.calls)
+		assert.Equal(t, int32(2), cnt.maxConc)
+
+		// Shared objects are created once
+		s2 := s1.InitArgs()[0].(Service2)
+		s3 := s1.InitArgs()[1].(Service3)
+		assert.Same(t, s2.InitArgs()[0], s3.InitArgs()[0])
+		s4, err := Get[Service4](c)
+		assert.Nil(t, err)
+		assert.Same(t, s4, s3.InitArgs()[0])
+	})
+
+	t.Run("Worker limit", func(t *testing.T) {
+		cnt := &counter{calls: map[string]int{}}
+		c, err := NewContainer(newProviders(cnt, 10*time.Millisecond, nil))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c, ParallelMode(1))
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), cnt.maxConc)
+	})
+
+	t.Run("Cached objects are reused", func(t *testing.T) {
+		cnt := &counter{calls: map[string]int{}}
+		c, err := NewContainer(newProviders(cnt, 0, nil), SetParallelMode(0))
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"s1": 1, "s2": 1, "s3": 1, "s4": 1, "s5": 1}, cnt.calls)
+	})
+
+	t.Run("Deterministic error", func(t *testing.T) {
+		errS3 := errors.New("errS3")
+		errS5 := errors.New("errS5")
+		for i := 0; i < 10; i++ {
+			cnt := &counter{calls: map[string]int{}}
+			c, err := NewContainer(newProviders(cnt, time.Millisecond,
+				map[string]error{"s3": errS3, "s5": errS5}), SetParallelMode(4))
+			assert.Nil(t, err)
+			_, err = Build[Service1](c)
+			// Sequential order: S4, S5, S2, S3, S1
+			assert.ErrorIs(t, err, errS5)
+			_, err = Get[Service4](c)
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	})
+
+	t.Run("Missing provider", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK}, SetParallelMode(4))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "ErrNotFound: provider not found for type 'autowire.Service3', "+
+			"required by provider 'github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3")
+	})
+
+	t.Run("Circular dependency", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_Fail_Need_Srv1}, SetParallelMode(4))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, ErrCircularDependency)
+	})
+
+	t.Run("Panic is propagated", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv3_OK,
+			func() Service2 { panic("s2 panics") }}, SetParallelMode(4))
+		assert.Nil(t, err)
+		assert.PanicsWithValue(t, "s2 panics", func() {
+			_, _ = Build[Service1](c)
+		})
+	})
+
+	t.Run("Non-shared mode builds sequentially", func(t *testing.T) {
+		cnt := &counter{calls: map[string]int{}}
+		c, err := NewContainer(newProviders(cnt, 0, nil), SetParallelMode(4))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c, NonSharedMode())
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"s1": 1, "s2": 1, "s3": 1, "s4": 2, "s5": 1}, cnt.calls)
+		assert.Equal(t, int32(1), cnt.maxConc)
+	})
+}
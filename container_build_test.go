@@ -2,7 +2,11 @@ package autowire
 
 import (
 	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,6 +74,42 @@ func TestContainerBuild_Failure(t *testing.T) {
 			"ErrCircularDependency: circular dependency detected at type 'autowire.Service1'")
 	})
 
+	t.Run("Context canceled during build", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		newSrv2 := func() Service2 {
+			cancel()
+			return &service2{}
+		}
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, newSrv2, NewSrv3_OK})
+		assert.Nil(t, err)
+		_, err = c.BuildWithCtx(ctx, typeFor[Service1]())
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Contains(t, err.Error(), "context canceled: build aborted at type 'autowire.Service1'")
+		// Objects created before the cancellation are not cached
+		_, err = c.Get(typeFor[Service2]())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Context deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK})
+		assert.Nil(t, err)
+		_, err = c.BuildWithCtx(ctx, typeFor[Service1]())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "build aborted at type 'autowire.Service1'")
+	})
+
+	t.Run("Failed build caches nothing", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK})
+		assert.Nil(t, err)
+		_, err = c.Build(typeFor[Service1]())
+		assert.ErrorIs(t, err, errTest1)
+		_, err = c.Get(typeFor[Service2]())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
 	t.Run("Requires context.Context, but not provide", func(t *testing.T) {
 		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Ctx})
 		assert.Nil(t, err)
@@ -168,4 +208,244 @@ func TestContainerBuild_Success(t *testing.T) {
 		assert.Equal(t, []any{s2.Interface().(Service2), s3.Interface().(Service3), []int{100, 200}},
 			s1.Interface().(Service1).InitArgs())
 	})
+
+	t.Run("Success, with overwriting by function", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK,
+			NewSrv4_OK, &struct1_OK})
+		assert.Nil(t, err)
+		s1, err := c.Build(typeFor[Service1](), NonSharedMode(), OverwriteWith(NewSrv3_OK_With_Need_Srv4))
+		assert.Nil(t, err)
+		s3 := s1.Interface().(Service1).InitArgs()[1].(Service3)
+		assert.Equal(t, 1, len(s3.InitArgs()))
+		assert.NotNil(t, s3.InitArgs()[0].(Service4))
+	})
+
+	t.Run("Success, with overwriting by provider set and struct", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK, &struct1_OK})
+		assert.Nil(t, err)
+		struct1Copy := struct1_OK
+		struct1Copy.Slice = []int{100, 200}
+		s1, err := c.Build(typeFor[Service1](), NonSharedMode(),
+			OverwriteWith(MustNewProviderSet(NewSrv3_OK_With_Need_Srv4, NewSrv4_OK), &struct1Copy))
+		assert.Nil(t, err)
+		args := s1.Interface().(Service1).InitArgs()
+		assert.Equal(t, 1, len(args[1].(Service3).InitArgs()))
+		assert.Equal(t, []int{100, 200}, args[2])
+	})
+
+	t.Run("Success, with overwriting target type", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK})
+		assert.Nil(t, err)
+		s3, err := c.Build(typeFor[Service3](), NonSharedMode(), OverwriteWith(NewSrv3_OK_With_Need_Srv4, NewSrv4_OK))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(s3.Interface().(Service3).InitArgs()))
+
+		// Type only provided by overwriting providers
+		s4, err := c.Build(typeFor[Service4](), NonSharedMode(), ProviderOverwrite[Service4](&service4{}))
+		assert.Nil(t, err)
+		assert.NotNil(t, s4.Interface().(Service4))
+	})
+
+	t.Run("Failure, with overwriting by invalid provider", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK})
+		assert.Nil(t, err)
+		_, err = c.Build(typeFor[Service3](), OverwriteWith(NewSrv3_OK, NewSrv3_OK_With_Need_Srv4))
+		assert.ErrorIs(t, err, ErrProviderDuplicated)
+		_, err = c.Build(typeFor[Service3](), OverwriteWith(123))
+		assert.ErrorIs(t, err, ErrProviderInvalid)
+		assert.Contains(t, err.Error(), "invalid overwriting providers")
+	})
+
+	t.Run("Success, overwrites bypass cached objects of affected types only", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK,
+			NewSrv4_OK, &struct1_OK})
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		s4, err := Build[Service4](c)
+		assert.Nil(t, err)
+
+		fakeS3 := &service3{}
+		s1Overwritten, err := Build[Service1](c, ProviderOverwrite[Service3](fakeS3))
+		assert.Nil(t, err)
+		assert.NotSame(t, s1, s1Overwritten)
+		args := s1Overwritten.InitArgs()
+		assert.Same(t, s1.InitArgs()[0], args[0]) // Service2 is reused
+		assert.Same(t, fakeS3, args[1])
+
+		// Overwriting with a function whose dependency is cached
+		s3, err := Build[Service3](c, OverwriteWith(NewSrv3_OK_With_Need_Srv4))
+		assert.Nil(t, err)
+		assert.Same(t, s4, s3.InitArgs()[0])
+	})
+
+	t.Run("Success, overwrites are isolated between builds", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		// Overwriting the container's provider set affects later builds only
+		publicS4 := &service4{}
+		c.ProviderSet().Overwrite(newValueProvider[Service4](publicS4, reflect.ValueOf(publicS4), callSite{}))
+
+		fakeS4 := &service4{}
+		s3, err := Build[Service3](c, NonSharedMode(), ProviderOverwrite[Service4](fakeS4))
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s3.InitArgs()[0])
+		s3, err = Build[Service3](c, NonSharedMode())
+		assert.Nil(t, err)
+		assert.Same(t, publicS4, s3.InitArgs()[0])
+	})
+
+	t.Run("Success, concurrent builds with different overwrites", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		c.ProviderSet().Overwrite(newValueProvider([]int{1}, reflect.ValueOf([]int{1}), callSite{}))
+		wg := sync.WaitGroup{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%2 == 0 {
+					s3, err := Build[Service3](c, NonSharedMode())
+					assert.Nil(t, err)
+					assert.Nil(t, s3.InitArgs()[0].(*service4).initArgs)
+					return
+				}
+				fakeS4 := &service4{serviceBase{initArgs: []any{i}}}
+				s3, err := Build[Service3](c, NonSharedMode(), ProviderOverwrite[Service4](fakeS4))
+				assert.Nil(t, err)
+				assert.Same(t, fakeS4, s3.InitArgs()[0])
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	t.Run("Success, concurrent builds share the same objects", func(t *testing.T) {
+		var created, closed int32
+		c, err := NewContainer([]any{
+			func() *countingCloser {
+				atomic.AddInt32(&created, 1)
+				time.Sleep(10 * time.Millisecond)
+				return &countingCloser{closed: &closed}
+			},
+			func(cc *countingCloser) Service1 { return &service1{serviceBase{initArgs: []any{cc}}} },
+		})
+		assert.Nil(t, err)
+		results := make([]Service1, 20)
+		wg := sync.WaitGroup{}
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				s1, err := Build[Service1](c)
+				assert.Nil(t, err)
+				results[i] = s1
+			}(i)
+		}
+		wg.Wait()
+
+		cached, err := Get[*countingCloser](c)
+		assert.Nil(t, err)
+		for _, s1 := range results {
+			assert.Same(t, results[0], s1)
+			assert.Same(t, cached, s1.InitArgs()[0])
+		}
+		// Concurrent builds wait for the one calling the provider
+		assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&closed))
+	})
+
+	t.Run("Success, concurrent builds share objects of failed builds", func(t *testing.T) {
+		var mu sync.Mutex
+		var created []*countingCloser
+		c, err := NewContainer([]any{
+			func() *countingCloser {
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				created = append(created, &countingCloser{closed: new(int32)})
+				return created[len(created)-1]
+			},
+			func(cc *countingCloser) Service1 { return &service1{serviceBase{initArgs: []any{cc}}} },
+			func(cc *countingCloser) (Service2, error) { return nil, errTest1 },
+		})
+		assert.Nil(t, err)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%2 == 0 {
+					_, err := Build[Service2](c)
+					assert.ErrorIs(t, err, errTest1)
+					return
+				}
+				_, err := Build[Service1](c)
+				assert.Nil(t, err)
+			}(i)
+		}
+		wg.Wait()
+
+		cached, err := Get[*countingCloser](c)
+		assert.Nil(t, err)
+		// The object used by successful builds is never closed by failed builds, the others are closed once
+		for _, cc := range created {
+			if cc == cached {
+				assert.Equal(t, int32(0), atomic.LoadInt32(cc.closed))
+			} else {
+				assert.Equal(t, int32(1), atomic.LoadInt32(cc.closed))
+			}
+		}
+	})
+
+	t.Run("Success, concurrent builds with circular dependency fail", func(t *testing.T) {
+		c, err := NewContainer([]any{
+			func(s2 Service2) Service1 { time.Sleep(10 * time.Millisecond); return &service1{} },
+			func(s1 Service1) Service2 { time.Sleep(10 * time.Millisecond); return &service2{} },
+		})
+		assert.Nil(t, err)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(2) //nolint:gomnd
+			go func() {
+				defer wg.Done()
+				_, err := Build[Service1](c)
+				assert.ErrorIs(t, err, ErrCircularDependency)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := Build[Service2](c)
+				assert.ErrorIs(t, err, ErrCircularDependency)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Success, objects built with overwrites are not cached", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK_With_Need_Srv4,
+			NewSrv4_OK, &struct1_OK})
+		assert.Nil(t, err)
+
+		fakeS4 := &service4{}
+		s1Fake, err := Build[Service1](c, ProviderOverwrite[Service4](fakeS4))
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s1Fake.InitArgs()[1].(Service3).InitArgs()[0])
+
+		// Unaffected objects are cached, affected ones are not
+		s2, err := Get[Service2](c)
+		assert.Nil(t, err)
+		assert.Same(t, s1Fake.InitArgs()[0], s2)
+		_, err = Get[Service1](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = Get[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = Get[Service4](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// Later builds without overwrites get the real objects
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		assert.NotSame(t, s1Fake, s1)
+		assert.NotSame(t, fakeS4, s1.InitArgs()[1].(Service3).InitArgs()[0])
+		assert.Same(t, s2, s1.InitArgs()[0])
+	})
 }
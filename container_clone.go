@@ -0,0 +1,60 @@
+package autowire
+
+// CloneOption option setter used when clone a container
+type CloneOption func(*cloneOptions)
+
+type cloneOptions struct {
+	copyObjects bool
+	configOpts  []ContainerConfigOption
+}
+
+// CloneObjects clone option for copying objects cached in the original container to the clone.
+// The objects are shared by both containers, so closing either container closes them.
+func CloneObjects() CloneOption {
+	return func(opts *cloneOptions) {
+		opts.copyObjects = true
+	}
+}
+
+// CloneConfig clone option for applying config options to the clone in addition to the configuration
+// of the original container
+func CloneConfig(configOpts ...ContainerConfigOption) CloneOption {
+	return func(opts *cloneOptions) {
+		opts.configOpts = append(opts.configOpts, configOpts...)
+	}
+}
+
+// Clone implementation of Container interface
+func (c *container) Clone(opts ...CloneOption) (Container, error) {
+	options := &cloneOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	clone := &container{
+		sharedMode:      c.sharedMode,
+		sealedMode:      c.sealedMode,
+		validationMode:  c.validationMode,
+		parallelWorkers: c.parallelWorkers,
+		providerSet:     c.ProviderSet().shallowClone(),
+		objects:         newObjectCache(nil),
+		observers:       append([]Observer{}, c.observers...),
+		logger:          c.logger,
+	}
+	if options.copyObjects {
+		// The cache is never modified, so it can be shared
+		clone.objects = c.cache()
+	}
+
+	// Only validators added by the config options are run, the others passed for the original container
+	for _, opt := range options.configOpts {
+		opt(clone)
+	}
+	for _, validator := range clone.validators {
+		if err := validator(clone); err != nil {
+			return nil, err
+		}
+	}
+	clone.validators = append(append([]func(Container) error{}, c.validators...), clone.validators...)
+	return clone, nil
+}
@@ -0,0 +1,62 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Clone(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK}, SetSharedMode(true))
+		assert.Nil(t, err)
+		s4, err := Build[Service4](c)
+		assert.Nil(t, err)
+
+		clone, err := c.Clone()
+		assert.Nil(t, err)
+		assert.True(t, clone.SharedMode())
+		assert.Equal(t, 0, len(clone.Objects()))
+
+		// Overwriting providers of the clone doesn't affect the original
+		fakeS4 := &service4{}
+		clone.ProviderSet().Overwrite(newValueProvider[Service4](fakeS4, reflect.ValueOf(fakeS4), callSite{}))
+		s3, err := Build[Service3](clone)
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s3.InitArgs()[0])
+		s3, err = Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Same(t, s4, s3.InitArgs()[0])
+		assert.Equal(t, 2, len(c.Objects()))
+		assert.Equal(t, 1, len(clone.Objects()))
+	})
+
+	t.Run("Success, with objects and config", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		s4, err := Build[Service4](c)
+		assert.Nil(t, err)
+
+		o := &recordingObserver{}
+		clone, err := c.Clone(CloneObjects(), CloneConfig(WithObserver(o)))
+		assert.Nil(t, err)
+		s4Clone, err := Get[Service4](clone)
+		assert.Nil(t, err)
+		assert.Same(t, s4, s4Clone)
+
+		_, err = Build[Service3](clone)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"start autowire.Service3", "hit autowire.Service4", "end autowire.Service3 false"},
+			o.events)
+		_, err = Get[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Failure, validation of config", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv2_OK})
+		assert.Nil(t, err)
+		_, err = c.Clone(CloneConfig(ValidateNoUnusedProviders(typeFor[Service3]())))
+		assert.ErrorIs(t, err, ErrProviderUnused)
+	})
+}
@@ -0,0 +1,37 @@
+package autowire
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Close implementation of Container interface
+func (c *container) Close() error {
+	c.mu.Lock()
+	objects := describeObjects(c.objects.all())
+	c.objects = newObjectCache(nil)
+	c.generation++
+	c.mu.Unlock()
+	return closeObjects(objects)
+}
+
+// closeObjects closes the objects which implement io.Closer in the reverse order.
+// Closing continues on failure, and the first error is returned.
+func closeObjects(objects []ObjectDescriptor) error {
+	var firstErr error
+	for i := len(objects) - 1; i >= 0; i-- {
+		obj := objects[i]
+		if kind := obj.Value.Kind(); (kind == reflect.Pointer || kind == reflect.Interface) && obj.Value.IsNil() {
+			continue
+		}
+		closer, ok := obj.Value.Interface().(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%w: failed to close object of type '%v'", err, obj.Type)
+		}
+	}
+	return firstErr
+}
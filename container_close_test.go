@@ -0,0 +1,126 @@
+package autowire
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closerA struct {
+	closed *[]string
+	err    error
+}
+
+func (c *closerA) Close() error {
+	*c.closed = append(*c.closed, "A")
+	return c.err
+}
+
+type countingCloser struct {
+	closed *int32
+}
+
+func (c *countingCloser) Close() error {
+	atomic.AddInt32(c.closed, 1)
+	return nil
+}
+
+type closerB struct {
+	closed *[]string
+}
+
+func (c *closerB) Close() error {
+	*c.closed = append(*c.closed, "B")
+	return nil
+}
+
+func TestContainer_Close(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{
+			func() *closerA { return &closerA{closed: &closed} },
+			func(a *closerA) *closerB { return &closerB{closed: &closed} },
+			func() *nilCloser { return nil },
+			NewSrv2_OK,
+		})
+		assert.Nil(t, err)
+		_, err = Build[*closerB](c)
+		assert.Nil(t, err)
+		_, err = Build[*nilCloser](c)
+		assert.Nil(t, err)
+		_, err = Build[Service2](c)
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.Close())
+		// Objects are closed before their dependencies
+		assert.Equal(t, []string{"B", "A"}, closed)
+		assert.Equal(t, 0, len(c.Objects()))
+
+		// Objects are closed only once
+		assert.Nil(t, c.Close())
+		assert.Equal(t, []string{"B", "A"}, closed)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{
+			func() *closerA { return &closerA{closed: &closed, err: errTest1} },
+			func(a *closerA) *closerB { return &closerB{closed: &closed} },
+		})
+		assert.Nil(t, err)
+		_, err = Build[*closerB](c)
+		assert.Nil(t, err)
+
+		err = c.Close()
+		assert.ErrorIs(t, err, errTest1)
+		assert.Contains(t, err.Error(), "failed to close object of type '*autowire.closerA'")
+		assert.Equal(t, []string{"B", "A"}, closed)
+	})
+
+	t.Run("Objects of failed builds are closed", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{
+			func() *closerA { return &closerA{closed: &closed} },
+			func(a *closerA) (*closerB, error) { return nil, errTest1 },
+		})
+		assert.Nil(t, err)
+		_, err = Build[*closerB](c)
+		assert.ErrorIs(t, err, errTest1)
+		assert.Equal(t, []string{"A"}, closed)
+		assert.Equal(t, 0, len(c.Objects()))
+	})
+
+	t.Run("Objects of builds in progress are not cached", func(t *testing.T) {
+		var closed []string
+		started := make(chan struct{})
+		release := make(chan struct{})
+		c, err := NewContainer([]any{
+			func() *closerA { return &closerA{closed: &closed} },
+			func(a *closerA) *closerB {
+				close(started)
+				<-release
+				return &closerB{closed: &closed}
+			},
+		})
+		assert.Nil(t, err)
+		done := make(chan error)
+		go func() {
+			_, err := Build[*closerB](c)
+			done <- err
+		}()
+		<-started
+		assert.Nil(t, c.Close())
+		close(release)
+		assert.Nil(t, <-done)
+
+		assert.Equal(t, 0, len(c.Objects()))
+		assert.Equal(t, 0, len(closed))
+	})
+}
+
+type nilCloser struct{}
+
+func (c *nilCloser) Close() error {
+	panic("must not be called")
+}
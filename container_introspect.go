@@ -0,0 +1,91 @@
+package autowire
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ProviderLifetime lifetime of objects created by a provider
+type ProviderLifetime string
+
+const (
+	// LifetimeShared objects are created once and cached in the container (shared mode)
+	LifetimeShared ProviderLifetime = "shared"
+	// LifetimeTransient objects are created on every build (non-shared mode)
+	LifetimeTransient ProviderLifetime = "transient"
+	// LifetimeStatic objects are given when the provider is created, such as fields of a struct provider
+	LifetimeStatic ProviderLifetime = "static"
+)
+
+// ProviderDescriptor describes a provider of a container
+type ProviderDescriptor struct {
+	// Info contains kind, name, and location of the provider
+	Info           ProviderInfo
+	TargetTypes    []reflect.Type
+	DependentTypes []reflect.Type
+	Lifetime       ProviderLifetime
+	Provider       Provider
+}
+
+// ObjectDescriptor describes an object cached in a container
+type ObjectDescriptor struct {
+	Type    reflect.Type
+	Value   reflect.Value
+	BuiltAt time.Time
+}
+
+// Providers implementation of Container interface
+func (c *container) Providers() []ProviderDescriptor {
+	providers := uniqueProviders(c.ProviderSet().GetAll())
+	ret := make([]ProviderDescriptor, 0, len(providers))
+	for _, provider := range providers {
+		info := provider.Info()
+		targetTypes := append([]reflect.Type{}, provider.TargetTypes()...)
+		sortTypes(targetTypes)
+		ret = append(ret, ProviderDescriptor{
+			Info:           info,
+			TargetTypes:    targetTypes,
+			DependentTypes: append([]reflect.Type{}, provider.DependentTypes()...),
+			Lifetime:       c.providerLifetime(info.Kind),
+			Provider:       provider,
+		})
+	}
+	return ret
+}
+
+func (c *container) providerLifetime(kind ProviderKind) ProviderLifetime {
+	switch {
+	case kind == ProviderKindStruct || kind == ProviderKindValue:
+		return LifetimeStatic
+	case c.sharedMode:
+		return LifetimeShared
+	default:
+		return LifetimeTransient
+	}
+}
+
+// Objects implementation of Container interface
+func (c *container) Objects() []ObjectDescriptor {
+	return describeObjects(c.cache().all())
+}
+
+// describeObjects returns descriptors of the objects in the order of building
+func describeObjects(objects map[reflect.Type]builtObject) []ObjectDescriptor {
+	types := make([]reflect.Type, 0, len(objects))
+	for typ := range objects {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return objects[types[i]].seq < objects[types[j]].seq
+	})
+	ret := make([]ObjectDescriptor, 0, len(types))
+	for _, typ := range types {
+		ret = append(ret, ObjectDescriptor{
+			Type:    typ,
+			Value:   objects[typ].value,
+			BuiltAt: objects[typ].builtAt,
+		})
+	}
+	return ret
+}
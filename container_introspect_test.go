@@ -0,0 +1,86 @@
+package autowire
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Providers(t *testing.T) {
+	t.Run("Shared mode", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, &Struct5_OK{}})
+		assert.Nil(t, err)
+
+		providers := c.Providers()
+		assert.Equal(t, 3, len(providers))
+
+		p0 := providers[0]
+		assert.Equal(t, ProviderKindStruct, p0.Info.Kind)
+		assert.Equal(t, []reflect.Type{typeFor[*int]()}, p0.TargetTypes)
+		assert.Equal(t, LifetimeStatic, p0.Lifetime)
+
+		p1 := providers[1]
+		assert.Equal(t, ProviderKindFunc, p1.Info.Kind)
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv3_OK_With_Need_Srv4", p1.Info.Name)
+		assert.Equal(t, "data_test.go", filepath.Base(p1.Info.File))
+		assert.Equal(t, []reflect.Type{typeFor[Service3]()}, p1.TargetTypes)
+		assert.Equal(t, []reflect.Type{typeFor[Service4]()}, p1.DependentTypes)
+		assert.Equal(t, LifetimeShared, p1.Lifetime)
+
+		p2 := providers[2]
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv4_OK", p2.Info.Name)
+		assert.Equal(t, 0, len(p2.DependentTypes))
+		assert.Equal(t, LifetimeShared, p2.Lifetime)
+	})
+
+	t.Run("Non-shared mode", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv4_OK}, SetSharedMode(false))
+		assert.Nil(t, err)
+		assert.Equal(t, LifetimeTransient, c.Providers()[0].Lifetime)
+	})
+}
+
+func TestContainer_Objects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv2_OK})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(c.Objects()))
+
+		s3, err := Build[Service3](c)
+		assert.Nil(t, err)
+		_, err = Build[Service2](c)
+		assert.Nil(t, err)
+
+		objects := c.Objects()
+		assert.Equal(t, 3, len(objects))
+		assert.Equal(t, typeFor[Service4](), objects[0].Type)
+		assert.Equal(t, typeFor[Service3](), objects[1].Type)
+		assert.Equal(t, typeFor[Service2](), objects[2].Type)
+		assert.Equal(t, s3, objects[1].Value.Interface())
+		assert.False(t, objects[0].BuiltAt.IsZero())
+		assert.False(t, objects[2].BuiltAt.Before(objects[1].BuiltAt))
+	})
+
+	t.Run("Failed build caches nothing", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+		assert.Equal(t, 0, len(c.Objects()))
+	})
+
+	t.Run("Objects built at the same time keep the order of building", func(t *testing.T) {
+		builtAt := time.Now()
+		objects := describeObjects(map[reflect.Type]builtObject{
+			typeFor[Service1](): {value: reflect.ValueOf(NewSrv1_OK()), builtAt: builtAt, seq: 3},
+			typeFor[Service2](): {value: reflect.ValueOf(NewSrv2_OK()), builtAt: builtAt, seq: 1},
+			typeFor[Service3](): {value: reflect.ValueOf(NewSrv3_OK()), builtAt: builtAt, seq: 2},
+		})
+		assert.Equal(t, typeFor[Service2](), objects[0].Type)
+		assert.Equal(t, typeFor[Service3](), objects[1].Type)
+		assert.Equal(t, typeFor[Service1](), objects[2].Type)
+	})
+}
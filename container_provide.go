@@ -0,0 +1,29 @@
+package autowire
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Provide implementation of Container interface
+func (c *container) Provide(providers ...any) error {
+	newProviderSet, err := parseProvidersAt(callerLocation(), providers...)
+	if err != nil {
+		return err
+	}
+	newProviders := newProviderSet.GetAll()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sealedMode && atomic.LoadInt32(&c.built) == 1 {
+		return fmt.Errorf("%w: providers can't be added after the container has built objects", ErrContainerSealed)
+	}
+	providerSet, err := c.providerSet.extend(newProviders)
+	if err != nil {
+		return err
+	}
+	c.providerSet = providerSet
+	c.generation++
+	c.logRegistration(newProviders)
+	return nil
+}
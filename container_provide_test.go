@@ -0,0 +1,90 @@
+package autowire
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Provide(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4})
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		assert.Nil(t, c.Provide(NewSrv4_OK, &Struct5_OK{}))
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(c.Providers()))
+	})
+
+	t.Run("Success, overwrites are kept", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4})
+		assert.Nil(t, err)
+		fakeS4 := &service4{}
+		c.ProviderSet().Overwrite(newValueProvider[Service4](fakeS4, reflect.ValueOf(fakeS4), callSite{}))
+		assert.Nil(t, c.Provide(NewSrv2_OK))
+		s3, err := Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s3.InitArgs()[0])
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4})
+		assert.Nil(t, err)
+		assert.ErrorIs(t, c.Provide(NewSrv3_OK), ErrProviderDuplicated)
+		assert.ErrorIs(t, c.Provide(NewSrv4_OK, NewSrv4_OK), ErrProviderDuplicated)
+		assert.ErrorIs(t, c.Provide(123), ErrProviderInvalid)
+		assert.ErrorIs(t, c.Provide(), ErrProviderInvalid)
+		// Nothing is added on failure
+		assert.Equal(t, 1, len(c.Providers()))
+	})
+
+	t.Run("Sealed mode", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4}, SetSealedMode(true))
+		assert.Nil(t, err)
+		assert.Nil(t, c.Provide(NewSrv4_OK))
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		assert.ErrorIs(t, c.Provide(NewSrv2_OK), ErrContainerSealed)
+
+		// Not sealed by default
+		c, err = NewContainer([]any{NewSrv4_OK})
+		assert.Nil(t, err)
+		_, err = Build[Service4](c)
+		assert.Nil(t, err)
+		assert.Nil(t, c.Provide(NewSrv2_OK))
+	})
+
+	t.Run("Concurrent provide and build", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		providers := []any{NewSrv1_OK, NewSrv2_OK, NewSrv5_OK, &Struct5_OK{}}
+		wg := sync.WaitGroup{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i < len(providers) {
+					assert.Nil(t, c.Provide(providers[i]))
+					return
+				}
+				_, err := Build[Service3](c)
+				assert.Nil(t, err)
+				_, _ = Get[Service4](c)
+				_ = c.Objects()
+				_ = c.Graph()
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 6, len(c.Providers()))
+		s3, err := Get[Service3](c)
+		assert.Nil(t, err)
+		s4, err := Get[Service4](c)
+		assert.Nil(t, err)
+		assert.Same(t, s4, s3.InitArgs()[0])
+	})
+}
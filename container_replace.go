@@ -0,0 +1,100 @@
+package autowire
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetValidationMode config option for setting `validationMode` for a container.
+// In validation mode, Replace and Remove fail with ErrNotFound when they would leave a dependency
+// of the remaining providers without a provider.
+func SetValidationMode(flag bool) ContainerConfigOption {
+	return func(c Container) {
+		c.setValidationMode(flag)
+	}
+}
+
+// setValidationMode implementation of Container interface
+func (c *container) setValidationMode(flag bool) {
+	c.validationMode = flag
+}
+
+// Replace implementation of Container interface
+func (c *container) Replace(provider any) error {
+	if provider == nil {
+		return fmt.Errorf("%w: provider must not be nil", ErrProviderInvalid)
+	}
+	newProv, ok := provider.(Provider)
+	if !ok {
+		var err error
+		if newProv, err = newProvider(provider, callerLocation()); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	providerSet, err := c.providerSet.replace(newProv)
+	if err != nil {
+		return err
+	}
+	return c.changeProviderSet(providerSet, newProv.TargetTypes())
+}
+
+// Remove implementation of Container interface
+func (c *container) Remove(targetType reflect.Type) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	providerSet, err := c.providerSet.remove(targetType)
+	if err != nil {
+		return err
+	}
+	return c.changeProviderSet(providerSet, []reflect.Type{targetType})
+}
+
+// changeProviderSet validates the new provider set in validation mode, then uses it in the container
+// and evicts cached objects of the changed types and every type depending on them.
+// The container must be locked.
+func (c *container) changeProviderSet(providerSet ProviderSet, changedTypes []reflect.Type) error {
+	oldGraph := newGraph(c.providerSet)
+	if c.validationMode {
+		if err := validateNoNewMissing(oldGraph, newGraph(providerSet)); err != nil {
+			return err
+		}
+	}
+
+	evictedTypes := make(map[reflect.Type]struct{}, len(changedTypes))
+	for _, typ := range changedTypes {
+		evictedTypes[typ] = struct{}{}
+		for _, dependent := range oldGraph.AllDependentsOf(typ) {
+			evictedTypes[dependent] = struct{}{}
+		}
+	}
+	objects := c.objects.all()
+	for typ := range evictedTypes {
+		delete(objects, typ)
+	}
+
+	c.providerSet = providerSet
+	c.objects = newObjectCache(objects)
+	// Objects of builds in progress may depend on the old providers
+	c.generation++
+	return nil
+}
+
+// validateNoNewMissing returns an error when the new graph has missing types which are not
+// missing in the old graph
+func validateNoNewMissing(oldGraph, newGraph *Graph) error {
+	oldMissing := map[reflect.Type]struct{}{}
+	for _, typ := range oldGraph.Missing() {
+		oldMissing[typ] = struct{}{}
+	}
+	for _, typ := range newGraph.Missing() {
+		if _, exist := oldMissing[typ]; exist {
+			continue
+		}
+		return fmt.Errorf("%w: type '%v' would have no provider, required by types %v",
+			ErrNotFound, typ, newGraph.DependentsOf(typ))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Replace(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK,
+			NewSrv4_OK, &struct1_OK})
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		s4, err := Build[Service4](c)
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.Replace(NewSrv3_OK_With_Need_Srv4))
+		// Objects of the replaced type and its dependents are evicted, others are kept
+		_, err = Get[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = Get[Service1](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		s2, err := Get[Service2](c)
+		assert.Nil(t, err)
+
+		s1New, err := Build[Service1](c)
+		assert.Nil(t, err)
+		assert.NotSame(t, s1, s1New)
+		assert.Same(t, s2, s1New.InitArgs()[0])
+		assert.Same(t, s4, s1New.InitArgs()[1].(Service3).InitArgs()[0])
+	})
+
+	t.Run("Success, objects of builds in progress are not cached", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		c, err := NewContainer([]any{
+			func(s3 Service3) Service1 {
+				close(started)
+				<-release
+				return &service1{serviceBase{initArgs: []any{s3}}}
+			},
+			NewSrv3_OK, NewSrv4_OK,
+		})
+		assert.Nil(t, err)
+		done := make(chan error)
+		go func() {
+			_, err := Build[Service1](c)
+			done <- err
+		}()
+		<-started
+		assert.Nil(t, c.Replace(NewSrv3_OK_With_Need_Srv4))
+		close(release)
+		assert.Nil(t, <-done)
+
+		// The objects were built with the replaced provider
+		_, err = Get[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = Get[Service1](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Success, with a provider object", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv4_OK})
+		assert.Nil(t, err)
+		provider := MustNewProvider(NewSrv4_OK_With_Need_Srv1)
+		assert.Nil(t, c.Replace(provider))
+		assert.Same(t, provider, c.Graph().ProviderOf(typeFor[Service4]()))
+	})
+
+	t.Run("Success, overwritten provider is replaced", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		fakeS4 := &service4{}
+		c.ProviderSet().Overwrite(newValueProvider[Service4](fakeS4, reflect.ValueOf(fakeS4), callSite{}))
+		s3, err := Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s3.InitArgs()[0])
+
+		provider := MustNewProvider(NewSrv4_OK)
+		assert.Nil(t, c.Replace(provider))
+		assert.Same(t, provider, c.Graph().ProviderOf(typeFor[Service4]()))
+		s3, err = Build[Service3](c)
+		assert.Nil(t, err)
+		assert.NotSame(t, fakeS4, s3.InitArgs()[0])
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK}, SetValidationMode(true))
+		assert.Nil(t, err)
+		assert.ErrorIs(t, c.Replace(nil), ErrProviderInvalid)
+		assert.ErrorIs(t, c.Replace(123), ErrProviderInvalid)
+		assert.ErrorIs(t, c.Replace(NewSrv2_OK), ErrNotFound)
+
+		// Validation mode: replacement requires a type without provider
+		err = c.Replace(NewSrv4_OK_With_Need_Srv1)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "type 'autowire.Service1' would have no provider")
+		assert.Equal(t, ProviderKindFunc, c.Providers()[1].Info.Kind)
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv4_OK", c.Providers()[1].Info.Name)
+	})
+}
+
+func TestContainer_Remove(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv2_OK})
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		_, err = Build[Service2](c)
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.Remove(typeFor[Service4]()))
+		assert.Equal(t, 1, len(c.Objects()))
+		_, err = Get[Service2](c)
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Success, overwritten provider is removed", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		fakeS4 := &service4{}
+		c.ProviderSet().Overwrite(newValueProvider[Service4](fakeS4, reflect.ValueOf(fakeS4), callSite{}))
+		s2 := &service2{}
+		c.ProviderSet().Overwrite(newValueProvider[Service2](s2, reflect.ValueOf(s2), callSite{}))
+
+		assert.Nil(t, c.Remove(typeFor[Service4]()))
+		_, err = Build[Service3](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		// Types only provided by overwritten providers can be removed
+		assert.Nil(t, c.Remove(typeFor[Service2]()))
+		_, err = Build[Service2](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, 1, len(c.Providers()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv2_OK}, SetValidationMode(true))
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+
+		assert.ErrorIs(t, c.Remove(typeFor[Service1]()), ErrNotFound)
+		err = c.Remove(typeFor[Service4]())
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "type 'autowire.Service4' would have no provider, "+
+			"required by types [autowire.Service3]")
+		assert.Equal(t, 2, len(c.Objects()))
+
+		// Types nobody depends on can be removed
+		assert.Nil(t, c.Remove(typeFor[Service2]()))
+		assert.Equal(t, 2, len(c.Providers()))
+	})
+}
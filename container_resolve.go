@@ -6,23 +6,32 @@ import (
 )
 
 // DependencyGraph dependency graph info of a type.
+// A dependency of type context.Context has no provider, it is passed via BuildWithCtx.
 type DependencyGraph struct {
 	TargetType   reflect.Type
+	Provider     ProviderInfo
 	Dependencies []DependencyGraph
 }
 
 // Resolve implementation of Container interface
 func (c *container) Resolve(targetType reflect.Type) (value DependencyGraph, err error) {
+	c.mu.RLock()
+	providerSet, objects := c.providerSet, c.objects
+	c.mu.RUnlock()
 	ctx := &Context{
 		sharedMode:     c.sharedMode,
-		providerSet:    c.providerSet,
-		objectMap:      c.objectMap,
+		providerSet:    providerSet,
+		cachedObjects:  objects,
 		resolvingTypes: make(map[reflect.Type]struct{}, 10), //nolint:gomnd
 	}
 	return c.resolve(ctx, targetType)
 }
 
 func (c *container) resolve(ctx *Context, targetType reflect.Type) (DependencyGraph, error) {
+	// The context is passed via BuildWithCtx, it is a leaf without provider
+	if isContextType(targetType) {
+		return DependencyGraph{TargetType: targetType}, nil
+	}
 	if _, exist := ctx.resolvingTypes[targetType]; exist {
 		return DependencyGraph{}, fmt.Errorf("%w: circular dependency detected at type '%v'",
 			ErrCircularDependency, targetType)
@@ -36,6 +45,7 @@ func (c *container) resolve(ctx *Context, targetType reflect.Type) (DependencyGr
 
 	depGraph := DependencyGraph{
 		TargetType: targetType,
+		Provider:   provider.Info(),
 	}
 	for _, dType := range provider.DependentTypes() {
 		dGraph, err := c.resolve(ctx, dType)
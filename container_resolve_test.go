@@ -54,18 +54,29 @@ func TestContainerResolve_Failure(t *testing.T) {
 		assert.Contains(t, err.Error(),
 			"ErrCircularDependency: circular dependency detected at type 'autowire.Service1'")
 	})
+}
 
-	t.Run("Requires context.Context, but not provide", func(t *testing.T) {
-		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Ctx})
+func TestContainerResolve_Success(t *testing.T) {
+	t.Run("Context is a leaf without provider", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Ctx, NewSrv3_OK})
 		assert.Nil(t, err)
-		_, err = Resolve[Service1](c)
-		assert.ErrorIs(t, err, ErrNotFound)
-		assert.Contains(t, err.Error(),
-			"ErrNotFound: provider not found for type 'context.Context'")
+		depGraph, err := Resolve[Service1](c)
+		assert.Nil(t, err)
+		ctxGraph := depGraph.Dependencies[0].Dependencies[0]
+		assert.Equal(t, typeContext, ctxGraph.TargetType)
+		assert.Equal(t, ProviderInfo{}, ctxGraph.Provider)
+		assert.Nil(t, ctxGraph.Dependencies)
+
+		data := depGraph.Export()
+		assert.Equal(t, 4, len(data.Nodes))
+		assert.Nil(t, data.Nodes[2].Provider)
+		assert.False(t, data.Nodes[2].Missing)
+		assert.Equal(t, DependencyContext, data.Edges[1].Kind)
+		assert.Contains(t, depGraph.DOT(), "\tn2 [label=\"context.Context\", style=\"rounded\"];\n")
+		assert.Contains(t, depGraph.DOT(), "\tn1 -> n2 [style=\"dashed\"];\n")
+		assert.Contains(t, depGraph.Mermaid(), "\tn1 -.-> n2\n")
 	})
-}
 
-func TestContainerResolve_Success(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK_With_Need_Srv4_Srv5,
 			NewSrv3_OK, NewSrv4_OK, NewSrv5_OK, &struct1_OK, &struct5_OK})
@@ -73,6 +84,7 @@ func TestContainerResolve_Success(t *testing.T) {
 		dg, err := c.Resolve(typeFor[Service1]())
 		assert.Nil(t, err)
 		assert.Equal(t, typeFor[Service1](), dg.TargetType)
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice", dg.Provider.Name)
 		assert.Equal(t, 3, len(dg.Dependencies))
 
 		dg1 := dg.Dependencies[0]
@@ -91,6 +103,7 @@ func TestContainerResolve_Success(t *testing.T) {
 
 		dg3 := dg.Dependencies[2]
 		assert.Equal(t, typeFor[[]int](), dg3.TargetType)
+		assert.Equal(t, "*autowire.Struct1_OK", dg3.Provider.Name)
 		assert.Equal(t, 0, len(dg3.Dependencies))
 	})
 }
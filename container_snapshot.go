@@ -0,0 +1,50 @@
+package autowire
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Snapshot a saved state of a container, see Container.Snapshot
+type Snapshot struct {
+	container   *container
+	providerSet ProviderSet
+	objects     *objectCache
+}
+
+// Snapshot implementation of Container interface
+func (c *container) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// The cache is never modified, so it can be shared
+	return &Snapshot{
+		container:   c,
+		providerSet: c.providerSet.shallowClone(),
+		objects:     c.objects,
+	}
+}
+
+// Restore implementation of Container interface
+func (c *container) Restore(snapshot *Snapshot) error {
+	if snapshot == nil || snapshot.container != c {
+		return fmt.Errorf("%w: snapshot was not taken from this container", ErrSnapshotInvalid)
+	}
+
+	c.mu.Lock()
+	var created []ObjectDescriptor
+	current := c.objects.all()
+	objects := make(map[reflect.Type]builtObject, len(current))
+	for _, obj := range describeObjects(current) {
+		// Sequence numbers never repeat, so the same number means the same object
+		if saved, exist := snapshot.objects.get(obj.Type); exist && saved.seq == current[obj.Type].seq {
+			objects[obj.Type] = current[obj.Type]
+			continue
+		}
+		created = append(created, obj)
+	}
+	c.objects = newObjectCache(objects)
+	c.providerSet = snapshot.providerSet.shallowClone()
+	c.generation++
+	c.mu.Unlock()
+	return closeObjects(created)
+}
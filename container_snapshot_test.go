@@ -0,0 +1,108 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Snapshot(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{
+			func() *closerA { return &closerA{closed: &closed} },
+			func(a *closerA) *closerB { return &closerB{closed: &closed} },
+			NewSrv3_OK_With_Need_Srv4,
+			NewSrv4_OK,
+		})
+		assert.Nil(t, err)
+		a, err := Build[*closerA](c)
+		assert.Nil(t, err)
+		s4, err := Build[Service4](c)
+		assert.Nil(t, err)
+
+		snapshot := c.Snapshot()
+		_, err = Build[*closerB](c)
+		assert.Nil(t, err)
+		fakeS4 := &service4{}
+		c.ProviderSet().Overwrite(newValueProvider[Service4](fakeS4, reflect.ValueOf(fakeS4), callSite{}))
+		s3, err := Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Same(t, fakeS4, s3.InitArgs()[0])
+
+		assert.Nil(t, c.Restore(snapshot))
+		// Objects created after the snapshot are closed
+		assert.Equal(t, []string{"B"}, closed)
+		assert.Equal(t, 2, len(c.Objects()))
+		a2, err := Get[*closerA](c)
+		assert.Nil(t, err)
+		assert.Same(t, a, a2)
+
+		// Providers are reverted
+		s3, err = Build[Service3](c)
+		assert.Nil(t, err)
+		assert.Same(t, s4, s3.InitArgs()[0])
+
+		// A snapshot can be restored multiple times
+		assert.Nil(t, c.Restore(snapshot))
+		assert.Equal(t, 2, len(c.Objects()))
+		assert.Equal(t, []string{"B"}, closed)
+	})
+
+	t.Run("Objects removed after snapshot are not restored", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{func() *closerA { return &closerA{closed: &closed} }})
+		assert.Nil(t, err)
+		_, err = Build[*closerA](c)
+		assert.Nil(t, err)
+		snapshot := c.Snapshot()
+		assert.Nil(t, c.Close())
+		_, err = Build[*closerA](c)
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.Restore(snapshot))
+		assert.Equal(t, []string{"A", "A"}, closed)
+		assert.Equal(t, 0, len(c.Objects()))
+	})
+
+	t.Run("Objects rebuilt at the same time are not restored", func(t *testing.T) {
+		var closed []string
+		c, err := NewContainer([]any{func() *closerA { return &closerA{closed: &closed} }})
+		assert.Nil(t, err)
+		_, err = Build[*closerA](c)
+		assert.Nil(t, err)
+		snapshot := c.Snapshot()
+		assert.Nil(t, c.Close())
+		_, err = Build[*closerA](c)
+		assert.Nil(t, err)
+
+		// Simulate a clock which doesn't advance between the builds
+		impl := c.(*container)
+		obj, _ := impl.objects.get(typeFor[*closerA]())
+		saved, _ := snapshot.objects.get(typeFor[*closerA]())
+		obj.builtAt = saved.builtAt
+		impl.objects = newObjectCache(map[reflect.Type]builtObject{typeFor[*closerA](): obj})
+
+		assert.Nil(t, c.Restore(snapshot))
+		assert.Equal(t, []string{"A", "A"}, closed)
+		assert.Equal(t, 0, len(c.Objects()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var closed []string
+		c1, err := NewContainer([]any{func() *closerA { return &closerA{closed: &closed, err: errTest1} }})
+		assert.Nil(t, err)
+		c2, err := NewContainer([]any{NewSrv4_OK})
+		assert.Nil(t, err)
+
+		assert.ErrorIs(t, c1.Restore(c2.Snapshot()), ErrSnapshotInvalid)
+		assert.ErrorIs(t, c1.Restore(nil), ErrSnapshotInvalid)
+
+		snapshot := c1.Snapshot()
+		_, err = Build[*closerA](c1)
+		assert.Nil(t, err)
+		assert.ErrorIs(t, c1.Restore(snapshot), errTest1)
+		assert.Equal(t, 0, len(c1.Objects()))
+	})
+}
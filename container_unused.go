@@ -0,0 +1,54 @@
+package autowire
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UnusedProviders implementation of Container interface
+func (c *container) UnusedProviders(roots ...reflect.Type) []Provider {
+	g := c.Graph()
+	usedTypes := make(map[reflect.Type]struct{}, len(g.types))
+	for _, root := range roots {
+		usedTypes[root] = struct{}{}
+		for _, typ := range g.AllDependenciesOf(root) {
+			usedTypes[typ] = struct{}{}
+		}
+	}
+
+	var unused []Provider
+	for _, provider := range uniqueProviders(c.ProviderSet().GetAll()) {
+		used := false
+		for _, targetType := range provider.TargetTypes() {
+			// A provider of multiple types may be replaced for some of them
+			if _, used = usedTypes[targetType]; used && sameProvider(g.ProviderOf(targetType), provider) {
+				break
+			}
+			used = false
+		}
+		if !used {
+			unused = append(unused, provider)
+		}
+	}
+	return unused
+}
+
+// ValidateNoUnusedProviders config option for failing container creation when there are providers
+// not reachable from any of the specified root types. ErrProviderUnused is returned in that case.
+func ValidateNoUnusedProviders(roots ...reflect.Type) ContainerConfigOption {
+	return func(c Container) {
+		c.addValidator(func(c Container) error {
+			unused := c.UnusedProviders(roots...)
+			if len(unused) == 0 {
+				return nil
+			}
+			names := make([]string, 0, len(unused))
+			for _, provider := range unused {
+				names = append(names, "'"+provider.Info().String()+"'")
+			}
+			return fmt.Errorf("%w: providers not reachable from root types: %s",
+				ErrProviderUnused, strings.Join(names, ", "))
+		})
+	}
+}
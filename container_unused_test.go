@@ -0,0 +1,52 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerUnusedProviders(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK, NewSrv4_OK, NewSrv5_OK, &struct1_OK, &struct5_OK})
+		assert.Nil(t, err)
+
+		unused := c.UnusedProviders(typeFor[Service2]())
+		names := make([]string, 0, len(unused))
+		for _, p := range unused {
+			names = append(names, p.Info().Name)
+		}
+		assert.Equal(t, []string{"*autowire.Struct1_OK", "*autowire.Struct5_OK",
+			"github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3", "github.com/tiendc/autowire.NewSrv3_OK"},
+			names)
+
+		// A struct provider is used when any of its fields is used
+		unused = c.UnusedProviders(typeFor[Service1](), typeFor[[]int]())
+		assert.Equal(t, 1, len(unused))
+		assert.Equal(t, "*autowire.Struct5_OK", unused[0].Info().Name)
+
+		assert.Equal(t, 7, len(c.UnusedProviders()))
+		assert.Equal(t, 2, len(UnusedProviders[Service1](c)))
+	})
+}
+
+func TestValidateNoUnusedProviders(t *testing.T) {
+	t.Run("Failure", func(t *testing.T) {
+		_, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK, NewSrv4_OK},
+			ValidateNoUnusedProviders(typeFor[Service1]()))
+		assert.ErrorIs(t, err, ErrProviderUnused)
+		assert.Contains(t, err.Error(), "ErrProviderUnused: providers not reachable from root types: "+
+			"'github.com/tiendc/autowire.NewSrv4_OK (data_test.go:")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		_, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK, NewSrv4_OK},
+			ValidateNoUnusedProviders(typeFor[Service1](), typeFor[Service4]()))
+		assert.Nil(t, err)
+		_, err = NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK},
+			ValidateNoUnusedProviders([]reflect.Type{typeFor[Service1]()}...))
+		assert.Nil(t, err)
+	})
+}
@@ -1,19 +1,78 @@
 package autowire
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Context a context object used in each building/resolving object
 type Context struct {
 	sharedMode bool
 
+	// parallelWorkers number of workers to build dependencies concurrently, parallel mode is
+	// disabled when this is less than 2
+	parallelWorkers int
+
+	// context is the context.Context passed via BuildWithCtx, nil when not passed.
+	// While a provider is built, this can be a child context derived by the provider.
+	context context.Context
+	// observerContext is the context derived by context observers when no context is passed
+	observerContext context.Context
+
 	providerSet ProviderSet
-	objectMap   map[reflect.Type]reflect.Value
+	// cachedObjects objects cached in the container when the build starts
+	cachedObjects *objectCache
+	// generation generation of the container when the build starts
+	generation uint64
+
+	// builtObjects holds objects created within the current build. They are only added to
+	// the container's object map when the build succeeds.
+	builtObjects map[reflect.Type]builtObject
+	// builtObjectsMu guards builtObjects in parallel mode
+	builtObjectsMu *sync.Mutex
+
+	// flights shares the construction of objects with concurrent builds of the container
+	flights *flightGroup
+	// usedFlights flights of objects used by the build, it is shared by the task contexts of parallel mode.
+	// waitingFlight the flight the context waits for. Both are guarded by the mutex of flights.
+	usedFlights   map[reflect.Type]*flight
+	waitingFlight *flight
+
+	// stubMissing enables stubbing interface types which have no provider (see StubMissingInterfaces)
+	stubMissing   bool
+	stubFactories []StubFactory
+
+	// overwrittenTypes types overwritten by options of the current build
+	overwrittenTypes map[reflect.Type]struct{}
+	// affectedTypes types which are overwritten or stubbed, or transitively depend on these types.
+	// Objects of these types cached in the container are not used in the current build,
+	// and objects of these types created in the build are not cached.
+	affectedTypes map[reflect.Type]struct{}
+
+	// optionErr error of applying context options, the build fails with this error
+	optionErr error
+
+	observers []Observer
+	logger    diagnosticLogger
 
 	resolvingTypes map[reflect.Type]struct{}
 }
 
+// builtObject an object created within a build
+type builtObject struct {
+	value   reflect.Value
+	builtAt time.Time
+	// seq orders objects by build, unlike builtAt it never repeats or goes backward
+	seq uint64
+}
+
+// buildSeq sequence number of the last built object, it must be accessed atomically
+var buildSeq uint64
+
 // ContextOption configuration setter for a context
 type ContextOption func(*Context)
 
@@ -24,9 +83,193 @@ func NonSharedMode() ContextOption {
 	}
 }
 
-// ProviderOverwrite overwrites a value for the current context
+// ProviderOverwrite overwrites a value for the current context.
+// Overwriting a value of type context.Context sets the context of the current build.
 func ProviderOverwrite[T any](val T) ContextOption {
+	caller := callerLocation()
+	return func(ctx *Context) {
+		if goCtx, ok := any(val).(context.Context); ok && isContextType(typeFor[T]()) {
+			ctx.context = goCtx
+			return
+		}
+		ctx.overwrite(newValueProvider(val, reflect.ValueOf(val), caller))
+	}
+}
+
+// OverwriteWith overwrites providers for the current context. The providers can be functions, struct
+// pointers, objects of type `Provider` or `ProviderSet`, in the same forms as accepted by NewContainer.
+// Dependencies of the overwriting providers are resolved from the container. If the providers are
+// invalid, the build fails with their error.
+func OverwriteWith(providers ...any) ContextOption {
+	providerSet, err := parseProvidersAt(callerLocation(), providers...)
 	return func(ctx *Context) {
-		ctx.providerSet.Overwrite(newValueProvider(val, reflect.ValueOf(val)))
+		if err != nil {
+			ctx.setOptionError(fmt.Errorf("%w: invalid overwriting providers", err))
+			return
+		}
+		for _, provider := range uniqueProviders(providerSet.GetAll()) {
+			ctx.overwrite(provider)
+		}
+	}
+}
+
+// overwrite overwrites the provider for the current context
+func (ctx *Context) overwrite(provider Provider) {
+	ctx.providerSet.Overwrite(provider)
+	if ctx.overwrittenTypes == nil {
+		ctx.overwrittenTypes = map[reflect.Type]struct{}{}
+	}
+	for _, targetType := range provider.TargetTypes() {
+		ctx.overwrittenTypes[targetType] = struct{}{}
+		ctx.logOverwrite(targetType, provider)
+	}
+}
+
+// resolveAffectedTypes finds the types which are overwritten or stubbed, or transitively depend on these types
+func (ctx *Context) resolveAffectedTypes() {
+	if len(ctx.overwrittenTypes) == 0 && !ctx.stubMissing {
+		return
+	}
+	dependents := map[reflect.Type][]reflect.Type{}
+	for _, provider := range uniqueProviders(ctx.providerSet.GetAll()) {
+		for _, dependentType := range provider.DependentTypes() {
+			dependents[dependentType] = append(dependents[dependentType], provider.TargetTypes()...)
+		}
+	}
+	ctx.affectedTypes = make(map[reflect.Type]struct{}, len(ctx.overwrittenTypes))
+	queue := make([]reflect.Type, 0, len(ctx.overwrittenTypes))
+	for typ := range ctx.overwrittenTypes {
+		ctx.affectedTypes[typ] = struct{}{}
+		queue = append(queue, typ)
+	}
+	for typ := range dependents {
+		if _, exist := ctx.affectedTypes[typ]; !exist && ctx.isStubbable(typ) {
+			ctx.affectedTypes[typ] = struct{}{}
+			queue = append(queue, typ)
+		}
+	}
+	for len(queue) > 0 {
+		typ := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[typ] {
+			if _, exist := ctx.affectedTypes[dependent]; !exist {
+				ctx.affectedTypes[dependent] = struct{}{}
+				queue = append(queue, dependent)
+			}
+		}
+	}
+}
+
+// setOptionError sets the error of applying an option, the first error is kept
+func (ctx *Context) setOptionError(err error) {
+	if ctx.optionErr == nil {
+		ctx.optionErr = err
+	}
+}
+
+// getObject gets an object of the type created previously in the container or in the current build
+func (ctx *Context) getObject(targetType reflect.Type) (reflect.Value, bool) {
+	if ctx.builtObjectsMu != nil {
+		ctx.builtObjectsMu.Lock()
+		defer ctx.builtObjectsMu.Unlock()
+	}
+	if obj, exist := ctx.builtObjects[targetType]; exist {
+		return obj.value, true
+	}
+	if _, affected := ctx.affectedTypes[targetType]; affected {
+		return reflect.Value{}, false
+	}
+	obj, exist := ctx.cachedObjects.get(targetType)
+	return obj.value, exist
+}
+
+// setObject stores an object created in the current build
+func (ctx *Context) setObject(targetType reflect.Type, value reflect.Value) {
+	ctx.addObject(targetType, builtObject{
+		value:   value,
+		builtAt: time.Now(),
+		seq:     atomic.AddUint64(&buildSeq, 1),
+	})
+}
+
+// addObject stores an object used in the current build which is not cached in the container
+func (ctx *Context) addObject(targetType reflect.Type, obj builtObject) {
+	if ctx.builtObjectsMu != nil {
+		ctx.builtObjectsMu.Lock()
+		defer ctx.builtObjectsMu.Unlock()
+	}
+	if ctx.builtObjects == nil {
+		ctx.builtObjects = map[reflect.Type]builtObject{}
+	}
+	ctx.builtObjects[targetType] = obj
+}
+
+// builtObject gets an object stored in the current build
+func (ctx *Context) builtObject(targetType reflect.Type) (builtObject, bool) {
+	if ctx.builtObjectsMu != nil {
+		ctx.builtObjectsMu.Lock()
+		defer ctx.builtObjectsMu.Unlock()
+	}
+	obj, exist := ctx.builtObjects[targetType]
+	return obj, exist
+}
+
+// baseContext returns the context of the current build, or a background context when not passed
+func (ctx *Context) baseContext() context.Context {
+	if ctx.context != nil {
+		return ctx.context
+	}
+	if ctx.observerContext != nil {
+		return ctx.observerContext
+	}
+	return context.Background()
+}
+
+// contextAs returns the context of the current build as the interface type when it implements the type
+func (ctx *Context) contextAs(typ reflect.Type) (reflect.Value, bool) {
+	if ctx.context == nil || typ.Kind() != reflect.Interface {
+		return reflect.Value{}, false
+	}
+	val := reflect.ValueOf(ctx.context)
+	if !val.Type().AssignableTo(typ) {
+		return reflect.Value{}, false
+	}
+	return val, true
+}
+
+// taskContext creates a copy of the context for building a type in a separate goroutine.
+// The copy shares objects built in the current build.
+func (ctx *Context) taskContext() *Context {
+	taskCtx := *ctx
+	taskCtx.resolvingTypes = make(map[reflect.Type]struct{}, 10) //nolint:gomnd
+	return &taskCtx
+}
+
+// sleep waits for the duration or until the context of the build is done
+func (ctx *Context) sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	var done <-chan struct{}
+	if ctx.context != nil {
+		done = ctx.context.Done()
+	}
+	select {
+	case <-timer.C:
+	case <-done:
+	}
+}
+
+// checkCanceled returns an error wrapping context.Canceled or context.DeadlineExceeded
+// when the context.Context of the build is done
+func (ctx *Context) checkCanceled(targetType reflect.Type) error {
+	if ctx.context == nil {
+		return nil
+	}
+	if err := ctx.context.Err(); err != nil {
+		return fmt.Errorf("%w: build aborted at type '%v'", err, targetType)
 	}
+	return nil
 }
@@ -95,6 +95,10 @@ func NewSrv1_Fail_With_Err() (Service1, error) {
 	return nil, errTest1
 }
 
+func NewSrv1_Fail_With_Err_With_Need_Srv2(s2 Service2) (Service1, error) {
+	return nil, errTest1
+}
+
 func NewSrv1_Fail_With_0_Ret_Value() {
 }
 
@@ -124,6 +128,10 @@ func NewSrv2_OK() Service2 {
 	return &service2{}
 }
 
+func NewSrv2_OK_With_Need_Ctx(ctx context.Context) Service2 {
+	return &service2{serviceBase{initArgs: []any{ctx}}}
+}
+
 func NewSrv2_OK_With_Need_Srv4_Srv5(s4 Service4, s5 Service5) (Service2, error) {
 	return &service2{serviceBase{initArgs: []any{s4, s5}}}, nil
 }
@@ -134,6 +142,10 @@ func NewSrv3_OK() Service3 {
 	return &service3{}
 }
 
+func NewSrv3_OK_With_Need_Srv4(s4 Service4) Service3 {
+	return &service3{serviceBase{initArgs: []any{s4}}}
+}
+
 // CREATE FUNCTIONS - Service 4
 
 func NewSrv4_OK() Service4 {
@@ -207,6 +219,10 @@ type Nested2 struct {
 	Map   map[int]int
 }
 
+type Struct8_OK struct {
+	Struct1 *Struct1_OK
+}
+
 var (
 	struct1_OK = Struct1_OK{
 		Int:   123,
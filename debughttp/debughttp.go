@@ -0,0 +1,285 @@
+// Package debughttp serves the state of an autowire container over HTTP, in the spirit of net/http/pprof.
+//
+// The handler serves the following pages relative to the path it is mounted at:
+//
+//	/                 index page with links to other pages
+//	/providers        providers of the container (JSON)
+//	/objects          objects cached in the container (JSON)
+//	/graph            dependency graph (HTML)
+//	/graph.json       dependency graph (JSON)
+//	/graph.dot        dependency graph (Graphviz DOT)
+//	/graph.mmd        dependency graph (Mermaid)
+//	/timings          build timings report (text), requires WithProfile
+//	/timings.json     build timings in Chrome trace event format, requires WithProfile
+//
+// Graph pages accept query parameters `type` to only show the graph of the specified types.
+// The HTML graph page shows the Mermaid source of the graph, it loads no external scripts unless
+// WithMermaidScript is specified.
+//
+//	mux.Handle("/debug/autowire/", http.StripPrefix("/debug/autowire", debughttp.NewHandler(container)))
+package debughttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tiendc/autowire"
+)
+
+// Option configuration setter for the handler
+type Option func(*handler)
+
+// WithProfile sets the profile used to serve build timings. The profile should be an observer
+// of the container (see autowire.NewProfile).
+func WithProfile(profile *autowire.Profile) Option {
+	return func(h *handler) {
+		h.profile = profile
+	}
+}
+
+// WithMermaidScript sets the URL of the Mermaid ES module used to render the graph on the HTML graph page,
+// such as "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.esm.min.mjs". By default, no script
+// is loaded and the page shows the Mermaid source of the graph.
+func WithMermaidScript(url string) Option {
+	return func(h *handler) {
+		h.mermaidScript = url
+	}
+}
+
+// handler an implementation of http.Handler serving container state
+type handler struct {
+	container     autowire.Container
+	profile       *autowire.Profile
+	mermaidScript string
+	mux           *http.ServeMux
+}
+
+// NewHandler creates a handler serving the state of the container
+func NewHandler(container autowire.Container, opts ...Option) http.Handler {
+	h := &handler{
+		container: container,
+		mux:       http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.mux.HandleFunc("/", h.serveIndex)
+	h.mux.HandleFunc("/providers", h.serveProviders)
+	h.mux.HandleFunc("/objects", h.serveObjects)
+	h.mux.HandleFunc("/graph", h.serveGraphHTML)
+	h.mux.HandleFunc("/graph.json", h.serveGraphJSON)
+	h.mux.HandleFunc("/graph.dot", h.serveGraphDOT)
+	h.mux.HandleFunc("/graph.mmd", h.serveGraphMermaid)
+	h.mux.HandleFunc("/timings", h.serveTimings)
+	h.mux.HandleFunc("/timings.json", h.serveTimingsJSON)
+	return h
+}
+
+// ServeHTTP implementation of http.Handler interface
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "" {
+		// Mounted with http.StripPrefix without trailing slash
+		r.URL.Path = "/"
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><title>autowire</title></head>
+<body>
+<h1>autowire</h1>
+<ul>
+<li><a href="providers">providers</a> ({{.NumProviders}})</li>
+<li><a href="objects">objects</a> ({{.NumObjects}})</li>
+<li><a href="graph">graph</a>
+(<a href="graph.json">json</a>, <a href="graph.dot">dot</a>, <a href="graph.mmd">mermaid</a>)</li>
+{{if .HasProfile}}<li><a href="timings">timings</a> (<a href="timings.json">trace</a>)</li>{{end}}
+</ul>
+</body>
+</html>
+`))
+
+func (h *handler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = indexTemplate.Execute(w, map[string]any{
+		"NumProviders": len(h.container.Providers()),
+		"NumObjects":   len(h.container.Objects()),
+		"HasProfile":   h.profile != nil,
+	})
+}
+
+// providerData JSON form of autowire.ProviderDescriptor
+type providerData struct {
+	Kind           autowire.ProviderKind     `json:"kind"`
+	Name           string                    `json:"name"`
+	File           string                    `json:"file,omitempty"`
+	Line           int                       `json:"line,omitempty"`
+	TargetTypes    []string                  `json:"targetTypes"`
+	DependentTypes []string                  `json:"dependentTypes"`
+	Lifetime       autowire.ProviderLifetime `json:"lifetime"`
+}
+
+func (h *handler) serveProviders(w http.ResponseWriter, _ *http.Request) {
+	providers := h.container.Providers()
+	data := make([]providerData, 0, len(providers))
+	for _, p := range providers {
+		data = append(data, providerData{
+			Kind:           p.Info.Kind,
+			Name:           p.Info.Name,
+			File:           p.Info.File,
+			Line:           p.Info.Line,
+			TargetTypes:    typeNames(p.TargetTypes),
+			DependentTypes: typeNames(p.DependentTypes),
+			Lifetime:       p.Lifetime,
+		})
+	}
+	writeJSON(w, data)
+}
+
+// objectData JSON form of autowire.ObjectDescriptor
+type objectData struct {
+	Type    string    `json:"type"`
+	BuiltAt time.Time `json:"builtAt"`
+}
+
+func (h *handler) serveObjects(w http.ResponseWriter, _ *http.Request) {
+	objects := h.container.Objects()
+	data := make([]objectData, 0, len(objects))
+	for _, obj := range objects {
+		data = append(data, objectData{Type: obj.Type.String(), BuiltAt: obj.BuiltAt})
+	}
+	writeJSON(w, data)
+}
+
+var graphTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html>
+<head><title>autowire graph</title></head>
+<body>
+<h1>Dependency graph</h1>
+<pre class="mermaid">
+{{.Mermaid}}</pre>
+<h2>Types</h2>
+<table>
+<tr><th>Type</th><th>Provider</th><th>Dependencies</th></tr>
+{{range .Nodes}}<tr><td>{{.Type}}</td><td>{{.Provider}}</td><td>{{.Dependencies}}</td></tr>
+{{end}}</table>
+{{if .MermaidScript}}<script type="module">
+const { default: mermaid } = await import({{.MermaidScript}});
+mermaid.initialize({ startOnLoad: true });
+</script>
+{{end}}</body>
+</html>
+`))
+
+func (h *handler) serveGraphHTML(w http.ResponseWriter, r *http.Request) {
+	graph := h.exportGraph(r)
+	type nodeRow struct {
+		Type         string
+		Provider     string
+		Dependencies string
+	}
+	dependencies := map[string][]string{}
+	for _, edge := range graph.Edges {
+		dependencies[edge.From] = append(dependencies[edge.From], edge.To)
+	}
+	nodeTypes := map[string]string{}
+	for _, node := range graph.Nodes {
+		nodeTypes[node.ID] = node.Type
+	}
+	rows := make([]nodeRow, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		row := nodeRow{Type: node.Type, Provider: "(missing)"}
+		if node.Provider != nil {
+			row.Provider = node.Provider.Name
+		}
+		deps := make([]string, 0, len(dependencies[node.ID]))
+		for _, id := range dependencies[node.ID] {
+			deps = append(deps, nodeTypes[id])
+		}
+		row.Dependencies = strings.Join(deps, ", ")
+		rows = append(rows, row)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = graphTemplate.Execute(w, map[string]any{
+		"Mermaid":       graph.Mermaid(),
+		"Nodes":         rows,
+		"MermaidScript": h.mermaidScript,
+	})
+}
+
+func (h *handler) serveGraphJSON(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, h.exportGraph(r))
+}
+
+func (h *handler) serveGraphDOT(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	_ = h.exportGraph(r).WriteDOT(w)
+}
+
+func (h *handler) serveGraphMermaid(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_ = h.exportGraph(r).WriteMermaid(w)
+}
+
+// exportGraph exports the graph of the types specified by query parameters `type`,
+// or the graph of every type when not specified
+func (h *handler) exportGraph(r *http.Request) autowire.GraphData {
+	names := r.URL.Query()["type"]
+	if len(names) == 0 {
+		return h.container.ExportGraph()
+	}
+	var types []reflect.Type
+	for _, typ := range h.container.Graph().Types() {
+		for _, name := range names {
+			if typ.String() == name {
+				types = append(types, typ)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return autowire.GraphData{Nodes: []autowire.GraphNodeData{}, Edges: []autowire.GraphEdgeData{}}
+	}
+	return h.container.ExportGraph(types...)
+}
+
+func (h *handler) serveTimings(w http.ResponseWriter, _ *http.Request) {
+	if h.profile == nil {
+		http.Error(w, "profile not configured, see debughttp.WithProfile", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_ = h.profile.WriteReport(w)
+}
+
+func (h *handler) serveTimingsJSON(w http.ResponseWriter, _ *http.Request) {
+	if h.profile == nil {
+		http.Error(w, "profile not configured, see debughttp.WithProfile", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = h.profile.WriteChromeTrace(w)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func typeNames(types []reflect.Type) []string {
+	names := make([]string, 0, len(types))
+	for _, typ := range types {
+		names = append(names, typ.String())
+	}
+	return names
+}
@@ -0,0 +1,154 @@
+package debughttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/autowire"
+)
+
+type Service1 struct {
+	Service2 *Service2
+}
+
+type Service2 struct{}
+
+func NewService1(s2 *Service2) *Service1 {
+	return &Service1{Service2: s2}
+}
+
+func NewService2() *Service2 {
+	return &Service2{}
+}
+
+func newTestServer(t *testing.T, withProfile bool) (*httptest.Server, autowire.Container) {
+	profile := autowire.NewProfile()
+	c, err := autowire.NewContainer([]any{NewService1, NewService2}, autowire.WithObserver(profile))
+	assert.Nil(t, err)
+	var opts []Option
+	if withProfile {
+		opts = append(opts, WithProfile(profile))
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug/autowire/", http.StripPrefix("/debug/autowire", NewHandler(c, opts...)))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, c
+}
+
+func get(t *testing.T, url string) (int, string, string) {
+	resp, err := http.Get(url) //nolint:gosec,noctx
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Index", func(t *testing.T) {
+		server, _ := newTestServer(t, false)
+		status, contentType, body := get(t, server.URL+"/debug/autowire/")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, contentType, "text/html")
+		assert.Contains(t, body, `<a href="providers">providers</a> (2)`)
+		assert.NotContains(t, body, "timings")
+
+		status, _, _ = get(t, server.URL+"/debug/autowire/unknown")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Providers", func(t *testing.T) {
+		server, _ := newTestServer(t, false)
+		status, contentType, body := get(t, server.URL+"/debug/autowire/providers")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "application/json", contentType)
+		var providers []providerData
+		assert.Nil(t, json.Unmarshal([]byte(body), &providers))
+		assert.Equal(t, 2, len(providers))
+		assert.Equal(t, autowire.ProviderKindFunc, providers[0].Kind)
+		assert.Equal(t, "github.com/tiendc/autowire/debughttp.NewService1", providers[0].Name)
+		assert.Equal(t, []string{"*debughttp.Service1"}, providers[0].TargetTypes)
+		assert.Equal(t, []string{"*debughttp.Service2"}, providers[0].DependentTypes)
+		assert.Equal(t, autowire.LifetimeShared, providers[0].Lifetime)
+	})
+
+	t.Run("Objects", func(t *testing.T) {
+		server, c := newTestServer(t, false)
+		_, err := autowire.Build[*Service1](c)
+		assert.Nil(t, err)
+		_, _, body := get(t, server.URL+"/debug/autowire/objects")
+		var objects []objectData
+		assert.Nil(t, json.Unmarshal([]byte(body), &objects))
+		assert.Equal(t, 2, len(objects))
+		assert.Equal(t, "*debughttp.Service2", objects[0].Type)
+		assert.False(t, objects[0].BuiltAt.IsZero())
+	})
+
+	t.Run("Graph", func(t *testing.T) {
+		server, _ := newTestServer(t, false)
+		_, _, body := get(t, server.URL+"/debug/autowire/graph.json")
+		var graph autowire.GraphData
+		assert.Nil(t, json.Unmarshal([]byte(body), &graph))
+		assert.Equal(t, 2, len(graph.Nodes))
+		assert.Equal(t, 1, len(graph.Edges))
+
+		_, _, body = get(t, server.URL+"/debug/autowire/graph.json?type=*debughttp.Service2")
+		assert.Nil(t, json.Unmarshal([]byte(body), &graph))
+		assert.Equal(t, 1, len(graph.Nodes))
+
+		_, _, body = get(t, server.URL+"/debug/autowire/graph.json?type=unknown")
+		assert.Equal(t, "{\n  \"nodes\": [],\n  \"edges\": []\n}\n", body)
+
+		_, contentType, body := get(t, server.URL+"/debug/autowire/graph.dot")
+		assert.Contains(t, contentType, "text/vnd.graphviz")
+		assert.True(t, strings.HasPrefix(body, "digraph"))
+
+		_, contentType, body = get(t, server.URL+"/debug/autowire/graph")
+		assert.Contains(t, contentType, "text/html")
+		assert.Contains(t, body, `<pre class="mermaid">`)
+		assert.Contains(t, body, "<td>*debughttp.Service1</td>"+
+			"<td>github.com/tiendc/autowire/debughttp.NewService1</td><td>*debughttp.Service2</td>")
+		assert.NotContains(t, body, "<script")
+
+		_, contentType, body = get(t, server.URL+"/debug/autowire/graph.mmd")
+		assert.Contains(t, contentType, "text/plain")
+		assert.True(t, strings.HasPrefix(body, "flowchart TD"))
+	})
+
+	t.Run("Graph with Mermaid script", func(t *testing.T) {
+		c, err := autowire.NewContainer([]any{NewService1, NewService2})
+		assert.Nil(t, err)
+		server := httptest.NewServer(NewHandler(c, WithMermaidScript("/static/mermaid.esm.min.mjs")))
+		defer server.Close()
+		_, _, body := get(t, server.URL+"/graph")
+		assert.Contains(t, body, `await import("/static/mermaid.esm.min.mjs")`)
+	})
+
+	t.Run("Timings", func(t *testing.T) {
+		server, _ := newTestServer(t, false)
+		status, _, _ := get(t, server.URL+"/debug/autowire/timings")
+		assert.Equal(t, http.StatusNotFound, status)
+
+		server, c := newTestServer(t, true)
+		_, err := autowire.Build[*Service1](c)
+		assert.Nil(t, err)
+
+		_, _, body := get(t, server.URL+"/debug/autowire/")
+		assert.Contains(t, body, `<a href="timings">timings</a>`)
+
+		status, _, body = get(t, server.URL+"/debug/autowire/timings")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, "*debughttp.Service1")
+
+		status, _, body = get(t, server.URL+"/debug/autowire/timings.json")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `"traceEvents"`)
+	})
+}
@@ -10,4 +10,7 @@ var (
 	ErrProviderInvalid    = errors.New("ErrProviderInvalid")
 	ErrProviderDuplicated = errors.New("ErrProviderDuplicated")
 	ErrCircularDependency = errors.New("ErrCircularDependency")
+	ErrProviderUnused     = errors.New("ErrProviderUnused")
+	ErrSnapshotInvalid    = errors.New("ErrSnapshotInvalid")
+	ErrContainerSealed    = errors.New("ErrContainerSealed")
 )
@@ -1,6 +1,7 @@
 package autowire
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -8,6 +9,7 @@ import (
 // funcProvider can take a function and execute it to create the target object
 type funcProvider struct {
 	baseProvider
+	options providerOptions
 }
 
 // TargetTypes implementation of Provider interface. Typically, this returns
@@ -18,6 +20,7 @@ func (p *funcProvider) TargetTypes() []reflect.Type {
 
 // DependentTypes implementation of Provider interface.
 // This returns a slice of all types of the input arguments of the function.
+// Arguments of type context.Context are included, although they are not built by providers.
 func (p *funcProvider) DependentTypes() []reflect.Type {
 	typ := p.sourceVal.Type()
 	numIn := typ.NumIn()
@@ -68,9 +71,10 @@ func (p *funcProvider) parse() error {
 // Build executes the source function and returns result.
 // In case there are dependencies, this function will execute corresponding providers to
 // collect all required objects to feed the current function.
-func (p *funcProvider) Build(ctx *Context, targetType reflect.Type) (reflect.Value, error) {
+func (p *funcProvider) Build(ctx *Context, targetType reflect.Type) (value reflect.Value, err error) {
 	if ctx.sharedMode {
-		if value, exist := ctx.objectMap[targetType]; exist {
+		if value, exist := ctx.getObject(targetType); exist {
+			ctx.observeCacheHit(targetType)
 			return value, nil
 		}
 	}
@@ -79,24 +83,151 @@ func (p *funcProvider) Build(ctx *Context, targetType reflect.Type) (reflect.Val
 		return reflect.Value{}, fmt.Errorf("%w: circular dependency detected at type '%v'",
 			ErrCircularDependency, targetType)
 	}
+
+	if ctx.sharedMode {
+		// Wait for a concurrent build constructing the object rather than constructing it again
+		value, exist, err := ctx.joinFlight(targetType)
+		if err != nil || exist {
+			if exist {
+				ctx.observeCacheHit(targetType)
+			}
+			return value, err
+		}
+		defer ctx.landFlight(targetType)
+		ctx.logCacheMiss(targetType)
+	}
+
 	ctx.resolvingTypes[targetType] = struct{}{}
 	defer func() {
 		delete(ctx.resolvingTypes, targetType)
 	}()
 
-	var inArgs []reflect.Value
-	for _, dependentType := range p.DependentTypes() {
-		argProv, err := ctx.providerSet.GetFor(dependentType)
-		if err != nil {
+	observeBuildEnd := ctx.observeBuildStart(targetType, p)
+	defer func() {
+		if r := recover(); r != nil {
+			observeBuildEnd(fmt.Errorf("provider panicked: %v", r))
+			panic(r)
+		}
+		observeBuildEnd(err)
+	}()
+
+	if p.options.deriveContext != nil || p.options.timeout > 0 {
+		parentCtx := ctx.context
+		childCtx := ctx.baseContext()
+		var finish func()
+		if p.options.deriveContext != nil {
+			childCtx, finish = p.options.deriveContext(childCtx)
+		}
+		ctx.context = childCtx
+		defer func() {
+			if finish != nil {
+				finish()
+			}
+			ctx.context = parentCtx
+		}()
+	}
+
+	dependentTypes := p.DependentTypes()
+	inArgs := make([]reflect.Value, 0, len(dependentTypes))
+	for _, dependentType := range dependentTypes {
+		if err := ctx.checkCanceled(targetType); err != nil {
 			return reflect.Value{}, err
 		}
-		argVal, err := argProv.Build(ctx, dependentType)
+		argVal, err := p.buildArg(ctx, dependentType)
 		if err != nil {
 			return reflect.Value{}, err
 		}
 		inArgs = append(inArgs, argVal)
 	}
 
+	if err := ctx.checkCanceled(targetType); err != nil {
+		return reflect.Value{}, err
+	}
+
+	result, attempts, err := p.callWithRetry(ctx, targetType, inArgs)
+	if err != nil {
+		if attempts > 1 {
+			return result, fmt.Errorf("%w: provider '%v' failed to build type '%v' after %d attempts",
+				err, p.info, targetType, attempts)
+		}
+		return result, fmt.Errorf("%w: provider '%v' failed to build type '%v'", err, p.info, targetType)
+	}
+
+	if ctx.sharedMode {
+		ctx.setObject(targetType, result)
+	}
+	return result, nil
+}
+
+// callWithRetry calls the function until it succeeds or the number of retries is exceeded.
+// This returns the result of the last attempt and the number of attempts.
+func (p *funcProvider) callWithRetry(ctx *Context, targetType reflect.Type, inArgs []reflect.Value) (
+	result reflect.Value, attempts int, err error,
+) {
+	for attempts = 1; ; attempts++ {
+		result, err = p.callWithTimeout(ctx, inArgs)
+		if err == nil || attempts > p.options.retries {
+			return result, attempts, err
+		}
+		ctx.observeBuildRetry(targetType, p, attempts, err)
+		if p.options.backoff != nil {
+			ctx.sleep(p.options.backoff(attempts))
+		}
+		if cancelErr := ctx.checkCanceled(targetType); cancelErr != nil {
+			return result, attempts, cancelErr
+		}
+	}
+}
+
+// callWithTimeout calls the function. When a timeout is set, the context arguments are replaced by
+// a context with the deadline, and the call is abandoned if it does not return before the deadline.
+// Arguments the context with the deadline is not assignable to are passed unchanged.
+func (p *funcProvider) callWithTimeout(ctx *Context, inArgs []reflect.Value) (reflect.Value, error) {
+	if p.options.timeout <= 0 {
+		return p.call(inArgs)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.context, p.options.timeout)
+	defer cancel()
+	timeoutVal := reflect.ValueOf(timeoutCtx)
+	args := make([]reflect.Value, len(inArgs))
+	for i, arg := range inArgs {
+		argType := p.sourceVal.Type().In(i)
+		if isContextType(argType) && timeoutVal.Type().AssignableTo(argType) {
+			arg = timeoutVal
+		}
+		args[i] = arg
+	}
+
+	type callResult struct {
+		value    reflect.Value
+		err      error
+		panicVal any
+	}
+	done := make(chan callResult, 1)
+	go func() {
+		var res callResult
+		defer func() {
+			res.panicVal = recover()
+			done <- res
+		}()
+		res.value, res.err = p.call(args)
+	}()
+
+	select {
+	case res := <-done:
+		if res.panicVal != nil {
+			panic(res.panicVal)
+		}
+		return res.value, res.err
+	case <-timeoutCtx.Done():
+		return reflect.Value{}, fmt.Errorf("%w: provider did not return within %v",
+			timeoutCtx.Err(), p.options.timeout)
+	}
+}
+
+// call calls the function and returns its result
+func (p *funcProvider) call(inArgs []reflect.Value) (reflect.Value, error) {
 	result := p.sourceVal.Call(inArgs)
 	var err error
 	if len(result) == 2 && result[1].IsValid() {
@@ -105,17 +236,49 @@ func (p *funcProvider) Build(ctx *Context, targetType reflect.Type) (reflect.Val
 			err, _ = iface.(error)
 		}
 	}
+	return result[0], err
+}
+
+// buildArg builds an argument of the function. Arguments of type context.Context get
+// the context of the current build, others are built by their providers.
+func (p *funcProvider) buildArg(ctx *Context, argType reflect.Type) (reflect.Value, error) {
+	if isContextType(argType) {
+		if ctx.context == nil {
+			return reflect.Value{}, fmt.Errorf("%w: provider not found for type '%v' (use BuildWithCtx to "+
+				"pass a context), required by provider '%v'", ErrNotFound, argType, p.info)
+		}
+		argVal := reflect.ValueOf(ctx.context)
+		if !argVal.Type().AssignableTo(argType) {
+			return reflect.Value{}, fmt.Errorf("%w: context of type '%v' is not assignable to type '%v', "+
+				"required by provider '%v'", ErrTypeCast, argVal.Type(), argType, p.info)
+		}
+		return argVal, nil
+	}
 
-	if err == nil && ctx.sharedMode {
-		ctx.objectMap[targetType] = result[0]
+	argProv, err := ctx.providerSet.GetFor(argType)
+	if err != nil {
+		// An interface embedding context.Context without provider accepts the context of the build
+		// when the context implements it
+		if argVal, ok := ctx.contextAs(argType); ok {
+			return argVal, nil
+		}
+		argVal, err := ctx.buildStub(argType, err)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w, required by provider '%v'", err, p.info)
+		}
+		return argVal, nil
 	}
-	return result[0], err
+	return argProv.Build(ctx, argType)
 }
 
 // newFuncProvider create a function provider
-func newFuncProvider(provSrc any, provVal reflect.Value) (*funcProvider, error) {
+func newFuncProvider(provSrc any, provVal reflect.Value, caller callSite) (*funcProvider, error) {
 	provider := &funcProvider{
-		baseProvider: baseProvider{source: provSrc, sourceVal: provVal},
+		baseProvider: baseProvider{
+			source:    provSrc,
+			sourceVal: provVal,
+			info:      newProviderInfo(provSrc, provVal, caller),
+		},
 	}
 	if err := provider.parse(); err != nil {
 		return nil, err
@@ -1,7 +1,9 @@
 package autowire
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,7 +42,9 @@ func TestFuncProvider_Failure(t *testing.T) {
 		_, err := parseProviders(NewSrv1_OK, NewSrv1_OK_With_Nil_Err)
 		assert.ErrorIs(t, err, ErrProviderDuplicated)
 		assert.Contains(t, err.Error(),
-			"ErrProviderDuplicated: duplicated provider for type 'autowire.Service1'")
+			"ErrProviderDuplicated: duplicated provider for type 'autowire.Service1', "+
+				"provided by 'github.com/tiendc/autowire.NewSrv1_OK (data_test.go:")
+		assert.Contains(t, err.Error(), "and 'github.com/tiendc/autowire.NewSrv1_OK_With_Nil_Err (data_test.go:")
 	})
 
 	t.Run("Func provider is variadic", func(t *testing.T) {
@@ -59,4 +63,17 @@ func TestFuncProvider_Success(t *testing.T) {
 		assert.Equal(t, typeFor[Service1](), ps1.GetAll()[0].TargetTypes()[0])
 		assert.Equal(t, reflect.TypeOf(NewSrv1_OK), reflect.TypeOf(ps1.GetAll()[0].Source()))
 	})
+
+	t.Run("Provider info", func(t *testing.T) {
+		ps1, err := parseProviders(NewSrv1_OK)
+		assert.Nil(t, err)
+		info := ps1.GetAll()[0].Info()
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv1_OK", info.Name)
+		assert.True(t, strings.HasSuffix(info.File, "data_test.go"))
+		assert.True(t, info.Line > 0)
+		assert.True(t, strings.HasSuffix(info.CallerFile, "func_provider_test.go"))
+		assert.True(t, info.CallerLine > 0)
+		assert.Equal(t, fmt.Sprintf("github.com/tiendc/autowire.NewSrv1_OK (data_test.go:%d)", info.Line),
+			info.String())
+	})
 }
@@ -0,0 +1,231 @@
+package autowire
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Graph is a dependency graph of every type provided by a container.
+// Unlike DependencyGraph, every type appears only once in the graph, so shared dependencies
+// are not duplicated. Types which are required but not provided are also included as missing,
+// as well as context types which are passed to providers via BuildWithCtx.
+type Graph struct {
+	types []reflect.Type
+	nodes map[reflect.Type]*graphNode
+}
+
+type graphNode struct {
+	provider     Provider
+	context      bool
+	dependencies []reflect.Type
+	dependents   []reflect.Type
+}
+
+// Graph implementation of Container interface
+func (c *container) Graph() *Graph {
+	return newGraph(c.ProviderSet())
+}
+
+// newGraph builds graph of every provider in the set. A provider of multiple types may be replaced
+// for some of them, so every type gets the provider the set maps it to.
+func newGraph(providerSet ProviderSet) *Graph {
+	g := &Graph{
+		nodes: map[reflect.Type]*graphNode{},
+	}
+	var providedTypes []reflect.Type
+	for _, provider := range providerSet.GetAll() {
+		for _, targetType := range provider.TargetTypes() {
+			if node := g.nodes[targetType]; node != nil && node.provider != nil {
+				continue
+			}
+			typeProvider, err := providerSet.GetFor(targetType)
+			if err != nil {
+				continue
+			}
+			g.getOrAddNode(targetType).provider = typeProvider
+			providedTypes = append(providedTypes, targetType)
+		}
+	}
+	for _, targetType := range providedTypes {
+		node := g.nodes[targetType]
+		node.dependencies = node.provider.DependentTypes()
+		for _, dType := range node.dependencies {
+			dNode := g.getOrAddNode(dType)
+			dNode.dependents = append(dNode.dependents, targetType)
+		}
+	}
+	sortTypes(g.types)
+	for _, node := range g.nodes {
+		sortTypes(node.dependents)
+	}
+	return g
+}
+
+func (g *Graph) getOrAddNode(typ reflect.Type) *graphNode {
+	node := g.nodes[typ]
+	if node == nil {
+		node = &graphNode{context: isContextType(typ)}
+		g.nodes[typ] = node
+		g.types = append(g.types, typ)
+	}
+	return node
+}
+
+// Types returns all types in the graph including missing ones, sorted by name
+func (g *Graph) Types() []reflect.Type {
+	return append([]reflect.Type{}, g.types...)
+}
+
+// ProviderOf returns provider of the specified type, or nil if the type is not provided
+func (g *Graph) ProviderOf(typ reflect.Type) Provider {
+	if node := g.nodes[typ]; node != nil {
+		return node.provider
+	}
+	return nil
+}
+
+// Missing returns types which are required by some providers but not provided.
+// Context types are not missing as they are passed via BuildWithCtx.
+func (g *Graph) Missing() []reflect.Type {
+	return g.filterTypes(func(node *graphNode) bool {
+		return node.provider == nil && !node.context
+	})
+}
+
+// Roots returns provided types which no other type depends on
+func (g *Graph) Roots() []reflect.Type {
+	return g.filterTypes(func(node *graphNode) bool {
+		return node.provider != nil && len(node.dependents) == 0
+	})
+}
+
+// Leaves returns provided types which have no dependencies
+func (g *Graph) Leaves() []reflect.Type {
+	return g.filterTypes(func(node *graphNode) bool {
+		return node.provider != nil && len(node.dependencies) == 0
+	})
+}
+
+// DependenciesOf returns types which the specified type directly depends on
+func (g *Graph) DependenciesOf(typ reflect.Type) []reflect.Type {
+	if node := g.nodes[typ]; node != nil {
+		return append([]reflect.Type{}, node.dependencies...)
+	}
+	return nil
+}
+
+// DependentsOf returns types which directly depend on the specified type
+func (g *Graph) DependentsOf(typ reflect.Type) []reflect.Type {
+	if node := g.nodes[typ]; node != nil {
+		return append([]reflect.Type{}, node.dependents...)
+	}
+	return nil
+}
+
+// AllDependenciesOf returns types which the specified type depends on directly or transitively
+func (g *Graph) AllDependenciesOf(typ reflect.Type) []reflect.Type {
+	return g.collect(typ, func(node *graphNode) []reflect.Type { return node.dependencies })
+}
+
+// AllDependentsOf returns types which depend on the specified type directly or transitively.
+// These are the types which can't be built anymore if the specified type is removed.
+// The specified type itself is never included, even when it is on a circular dependency.
+func (g *Graph) AllDependentsOf(typ reflect.Type) []reflect.Type {
+	return g.collect(typ, func(node *graphNode) []reflect.Type { return node.dependents })
+}
+
+// TopologicalOrder returns provided types in an order that every type comes after its dependencies.
+// Types of the same depth are sorted by name. Returns ErrCircularDependency if the graph has a cycle.
+func (g *Graph) TopologicalOrder() ([]reflect.Type, error) {
+	numDeps := make(map[reflect.Type]int, len(g.nodes))
+	var ready []reflect.Type
+	for _, typ := range g.types {
+		node := g.nodes[typ]
+		if node.provider == nil {
+			continue
+		}
+		for _, dType := range node.dependencies {
+			if g.nodes[dType].provider != nil {
+				numDeps[typ]++
+			}
+		}
+		if numDeps[typ] == 0 {
+			ready = append(ready, typ)
+		}
+	}
+
+	result := make([]reflect.Type, 0, len(g.types))
+	for len(ready) > 0 {
+		result = append(result, ready...)
+		var next []reflect.Type
+		for _, typ := range ready {
+			for _, dependent := range g.nodes[typ].dependents {
+				numDeps[dependent]--
+				if numDeps[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sortTypes(next)
+		ready = next
+	}
+
+	for _, typ := range g.types {
+		if numDeps[typ] > 0 {
+			return nil, fmt.Errorf("%w: circular dependency detected at type '%v'",
+				ErrCircularDependency, g.findCycleFrom(typ, numDeps))
+		}
+	}
+	return result, nil
+}
+
+// findCycleFrom follows unresolved dependencies from the specified type until a type is
+// visited twice, that type is on a cycle
+func (g *Graph) findCycleFrom(typ reflect.Type, numDeps map[reflect.Type]int) reflect.Type {
+	visited := map[reflect.Type]struct{}{}
+	for {
+		if _, exist := visited[typ]; exist {
+			return typ
+		}
+		visited[typ] = struct{}{}
+		for _, dType := range g.nodes[typ].dependencies {
+			if numDeps[dType] > 0 {
+				typ = dType
+				break
+			}
+		}
+	}
+}
+
+func (g *Graph) filterTypes(fn func(*graphNode) bool) []reflect.Type {
+	var result []reflect.Type
+	for _, typ := range g.types {
+		if fn(g.nodes[typ]) {
+			result = append(result, typ)
+		}
+	}
+	return result
+}
+
+// collect collects types reachable from the specified type via the specified edges
+func (g *Graph) collect(typ reflect.Type, edges func(*graphNode) []reflect.Type) []reflect.Type {
+	node := g.nodes[typ]
+	if node == nil {
+		return nil
+	}
+	visited := map[reflect.Type]struct{}{typ: {}}
+	var result []reflect.Type
+	queue := append([]reflect.Type{}, edges(node)...)
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if _, exist := visited[t]; exist {
+			continue
+		}
+		visited[t] = struct{}{}
+		result = append(result, t)
+		queue = append(queue, edges(g.nodes[t])...)
+	}
+	sortTypes(result)
+	return result
+}
@@ -0,0 +1,79 @@
+package autowire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// DOT returns the dependency graph in Graphviz DOT format.
+// Shared dependencies are rendered as a single node, missing and circular dependencies are highlighted.
+func (g DependencyGraph) DOT() string {
+	return g.Export().DOT()
+}
+
+// DOT returns the graph in Graphviz DOT format
+func (g GraphData) DOT() string {
+	var sb strings.Builder
+	_ = g.WriteDOT(&sb)
+	return sb.String()
+}
+
+// WriteDOT writes the graph in Graphviz DOT format
+func (g GraphData) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("digraph autowire {\n")
+	_, _ = bw.WriteString("\tnode [shape=box];\n")
+	for i := range g.Nodes {
+		node := &g.Nodes[i]
+		label := node.Type
+		var attrs string
+		switch {
+		case node.Missing:
+			label += "\n(missing)"
+			attrs = `, color="red", fontcolor="red", style="dashed"`
+		case node.Circular:
+			label += "\n" + node.providerName()
+			attrs = `, color="orange", style="bold"`
+		case node.Provider == nil: // context types are passed via BuildWithCtx
+			attrs = `, style="rounded"`
+		default:
+			label += "\n" + node.providerName()
+		}
+		_, _ = fmt.Fprintf(bw, "\t%s [label=%s%s];\n", node.ID, dotQuote(label), attrs)
+	}
+	for _, edge := range g.Edges {
+		var attrs string
+		switch {
+		case edge.Circular:
+			attrs = ` [color="orange", style="bold"]`
+		case edge.Kind == DependencyContext:
+			attrs = ` [style="dashed"]`
+		}
+		_, _ = fmt.Fprintf(bw, "\t%s -> %s%s;\n", edge.From, edge.To, attrs)
+	}
+	_, _ = bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// WriteDOT implementation of Container interface
+func (c *container) WriteDOT(w io.Writer, targetTypes ...reflect.Type) error {
+	return c.ExportGraph(targetTypes...).WriteDOT(w)
+}
+
+func (n *GraphNodeData) providerName() string {
+	if n.Provider == nil {
+		return ""
+	}
+	return n.Provider.Name
+}
+
+// dotQuote quotes a string as a DOT identifier
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
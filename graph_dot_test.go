@@ -0,0 +1,77 @@
+package autowire
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyGraphDOT(t *testing.T) {
+	t.Run("Shared dependencies are deduplicated", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv5_OK})
+		assert.Nil(t, err)
+		dg, err := Resolve[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, "digraph autowire {\n"+
+			"\tnode [shape=box];\n"+
+			"\tn0 [label=\"autowire.Service1\\ngithub.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3\"];\n"+
+			"\tn1 [label=\"autowire.Service2\\ngithub.com/tiendc/autowire.NewSrv2_OK_With_Need_Srv4_Srv5\"];\n"+
+			"\tn2 [label=\"autowire.Service4\\ngithub.com/tiendc/autowire.NewSrv4_OK\"];\n"+
+			"\tn3 [label=\"autowire.Service5\\ngithub.com/tiendc/autowire.NewSrv5_OK\"];\n"+
+			"\tn4 [label=\"autowire.Service3\\ngithub.com/tiendc/autowire.NewSrv3_OK_With_Need_Srv4\"];\n"+
+			"\tn0 -> n1;\n"+
+			"\tn1 -> n2;\n"+
+			"\tn1 -> n3;\n"+
+			"\tn0 -> n4;\n"+
+			"\tn4 -> n2;\n"+
+			"}\n", dg.DOT())
+	})
+}
+
+func TestContainerWriteDOT(t *testing.T) {
+	t.Run("Missing and circular dependencies are highlighted", func(t *testing.T) {
+		// S1 -> S2 -> S4 -> S1, S2 -> S5 (missing)
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK, NewSrv4_OK_With_Need_Srv1})
+		assert.Nil(t, err)
+		buf := bytes.NewBuffer(nil)
+		err = c.WriteDOT(buf, typeFor[Service1]())
+		assert.Nil(t, err)
+		out := buf.String()
+		assert.Contains(t, out, "\tn2 [label=\"autowire.Service4\\n"+
+			"github.com/tiendc/autowire.NewSrv4_OK_With_Need_Srv1\"];\n")
+		assert.Contains(t, out, "\tn0 [label=\"autowire.Service1\\n"+
+			"github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3\", color=\"orange\", style=\"bold\"];\n")
+		assert.Contains(t, out, "\tn3 [label=\"autowire.Service5\\n(missing)\", "+
+			"color=\"red\", fontcolor=\"red\", style=\"dashed\"];\n")
+		assert.Contains(t, out, "\tn2 -> n0 [color=\"orange\", style=\"bold\"];\n")
+		assert.Contains(t, out, "\tn1 -> n3;\n")
+	})
+
+	t.Run("Whole container", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK})
+		assert.Nil(t, err)
+		buf := bytes.NewBuffer(nil)
+		err = c.WriteDOT(buf)
+		assert.Nil(t, err)
+		// Types are visited in sorted order, nodes are shared
+		assert.Equal(t, fmt.Sprintf("digraph autowire {\n"+
+			"\tnode [shape=box];\n"+
+			"\tn0 [label=%s];\n"+
+			"\tn1 [label=%s];\n"+
+			"\tn2 [label=%s];\n"+
+			"\tn0 -> n1;\n"+
+			"\tn0 -> n2;\n"+
+			"}\n",
+			dotQuote("autowire.Service1\ngithub.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3"),
+			dotQuote("autowire.Service2\ngithub.com/tiendc/autowire.NewSrv2_OK"),
+			dotQuote("autowire.Service3\ngithub.com/tiendc/autowire.NewSrv3_OK")), buf.String())
+	})
+
+	t.Run("Quote special characters", func(t *testing.T) {
+		assert.Equal(t, `"a\"b\\c\nd"`, dotQuote("a\"b\\c\nd"))
+	})
+}
@@ -0,0 +1,217 @@
+package autowire
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// DependencyKind kind of a dependency edge in exported graphs
+type DependencyKind string
+
+const (
+	// DependencyRequired the dependent object can't be built without the dependency
+	DependencyRequired DependencyKind = "required"
+	// DependencyContext the dependency is the context passed to the build via BuildWithCtx
+	DependencyContext DependencyKind = "context"
+)
+
+// GraphData serializable form of dependency graphs with one node per type.
+// It has a stable JSON schema, so exported graphs can be stored and compared between releases.
+type GraphData struct {
+	Nodes []GraphNodeData `json:"nodes"`
+	Edges []GraphEdgeData `json:"edges"`
+}
+
+// GraphNodeData a node of GraphData
+type GraphNodeData struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	PkgPath  string             `json:"pkgPath,omitempty"`
+	Provider *GraphProviderData `json:"provider,omitempty"`
+	Missing  bool               `json:"missing,omitempty"`
+	Circular bool               `json:"circular,omitempty"`
+}
+
+// GraphProviderData provider info of a node of GraphData
+type GraphProviderData struct {
+	Kind       ProviderKind `json:"kind"`
+	Name       string       `json:"name"`
+	File       string       `json:"file,omitempty"`
+	Line       int          `json:"line,omitempty"`
+	CallerFile string       `json:"callerFile,omitempty"`
+	CallerLine int          `json:"callerLine,omitempty"`
+}
+
+// GraphEdgeData an edge of GraphData which goes from a type to one of its dependencies
+type GraphEdgeData struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Kind     DependencyKind `json:"kind"`
+	Circular bool           `json:"circular,omitempty"`
+}
+
+// Export flattens the dependency graph into a serializable graph
+func (g DependencyGraph) Export() GraphData {
+	b := newGraphDataBuilder()
+	b.addDependencyGraph(&g)
+	return b.data
+}
+
+// Export exports the graph of the specified types and their dependencies in a serializable form.
+// When no type is specified, every type in the graph is exported.
+// Missing types and edges forming circular dependencies are marked.
+func (g *Graph) Export(targetTypes ...reflect.Type) GraphData {
+	if len(targetTypes) == 0 {
+		targetTypes = g.types
+	}
+	b := newGraphDataBuilder()
+	visiting := map[reflect.Type]bool{}
+	for _, targetType := range targetTypes {
+		g.exportType(b, targetType, visiting)
+	}
+	return b.data
+}
+
+// exportType adds the type and its dependencies in depth-first order.
+// The visiting map holds `true` for types on the current path and `false` for visited types.
+func (g *Graph) exportType(b *graphDataBuilder, typ reflect.Type, visiting map[reflect.Type]bool) {
+	onPath, visited := visiting[typ]
+	if onPath {
+		b.nodeOf(typ).Circular = true
+		return
+	}
+	if visited {
+		return
+	}
+	visiting[typ] = true
+	defer func() {
+		visiting[typ] = false
+	}()
+
+	node := g.nodes[typ]
+	if node == nil || node.provider == nil {
+		if !isContextType(typ) {
+			b.nodeOf(typ).Missing = true
+		}
+		return
+	}
+	b.setProvider(typ, node.provider.Info())
+	for _, dType := range node.dependencies {
+		b.addEdge(typ, dType, visiting[dType])
+		g.exportType(b, dType, visiting)
+	}
+}
+
+// ExportGraph implementation of Container interface
+func (c *container) ExportGraph(targetTypes ...reflect.Type) GraphData {
+	return c.Graph().Export(targetTypes...)
+}
+
+// graphDataBuilder flattens dependency graphs into a deduplicated GraphData.
+// Nodes and edges are ordered by their first appearance.
+type graphDataBuilder struct {
+	data    GraphData
+	nodeMap map[reflect.Type]int
+	edgeMap map[[2]reflect.Type]int
+}
+
+func newGraphDataBuilder() *graphDataBuilder {
+	return &graphDataBuilder{
+		data:    GraphData{Nodes: []GraphNodeData{}, Edges: []GraphEdgeData{}},
+		nodeMap: map[reflect.Type]int{},
+		edgeMap: map[[2]reflect.Type]int{},
+	}
+}
+
+// addDependencyGraph adds the dependency graph tree in depth-first order
+func (b *graphDataBuilder) addDependencyGraph(depGraph *DependencyGraph) {
+	if isContextType(depGraph.TargetType) {
+		b.nodeOf(depGraph.TargetType)
+		return
+	}
+	b.setProvider(depGraph.TargetType, depGraph.Provider)
+	for i := range depGraph.Dependencies {
+		dGraph := &depGraph.Dependencies[i]
+		b.addEdge(depGraph.TargetType, dGraph.TargetType, false)
+		b.addDependencyGraph(dGraph)
+	}
+}
+
+func (b *graphDataBuilder) setProvider(typ reflect.Type, info ProviderInfo) {
+	node := b.nodeOf(typ)
+	if node.Provider != nil {
+		return
+	}
+	kind := info.Kind
+	if kind == "" {
+		kind = ProviderKindCustom
+	}
+	node.Provider = &GraphProviderData{
+		Kind:       kind,
+		Name:       info.Name,
+		File:       info.File,
+		Line:       info.Line,
+		CallerFile: info.CallerFile,
+		CallerLine: info.CallerLine,
+	}
+}
+
+func (b *graphDataBuilder) addEdge(from, to reflect.Type, circular bool) {
+	fromID, toID := b.nodeOf(from).ID, b.nodeOf(to).ID
+	key := [2]reflect.Type{from, to}
+	edgeIdx, exist := b.edgeMap[key]
+	if !exist {
+		kind := DependencyRequired
+		if isContextType(to) {
+			kind = DependencyContext
+		}
+		edgeIdx = len(b.data.Edges)
+		b.data.Edges = append(b.data.Edges, GraphEdgeData{
+			From: fromID,
+			To:   toID,
+			Kind: kind,
+		})
+		b.edgeMap[key] = edgeIdx
+	}
+	if circular {
+		b.data.Edges[edgeIdx].Circular = true
+	}
+}
+
+// nodeOf returns node of the type, adds a new one if not exist.
+// The returned pointer is valid until the next node is added.
+func (b *graphDataBuilder) nodeOf(typ reflect.Type) *GraphNodeData {
+	idx, exist := b.nodeMap[typ]
+	if !exist {
+		idx = len(b.data.Nodes)
+		b.data.Nodes = append(b.data.Nodes, GraphNodeData{
+			ID:      "n" + strconv.Itoa(idx),
+			Type:    typ.String(),
+			PkgPath: typePkgPath(typ),
+		})
+		b.nodeMap[typ] = idx
+	}
+	return &b.data.Nodes[idx]
+}
+
+// typePkgPath returns package path of a type. For pointer, slice, array, and map types,
+// package path of the element type is returned.
+func typePkgPath(typ reflect.Type) string {
+	for typ.Name() == "" {
+		switch typ.Kind() { //nolint:exhaustive
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			typ = typ.Elem()
+		default:
+			return ""
+		}
+	}
+	return typ.PkgPath()
+}
+
+// sortTypes sorts the types by their string representation to get stable output
+func sortTypes(types []reflect.Type) {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+}
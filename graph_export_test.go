@@ -0,0 +1,99 @@
+package autowire
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyGraphExport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_Struct1, NewSrv2_OK, NewSrv3_OK,
+			&Struct8_OK{Struct1: &struct1_OK}})
+		assert.Nil(t, err)
+		dg, err := Resolve[Service1](c)
+		assert.Nil(t, err)
+		data := dg.Export()
+
+		assert.Equal(t, 4, len(data.Nodes))
+		n0 := data.Nodes[0]
+		assert.Equal(t, "n0", n0.ID)
+		assert.Equal(t, "autowire.Service1", n0.Type)
+		assert.Equal(t, "github.com/tiendc/autowire", n0.PkgPath)
+		assert.Equal(t, ProviderKindFunc, n0.Provider.Kind)
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3_Struct1", n0.Provider.Name)
+		assert.NotEmpty(t, n0.Provider.File)
+		assert.NotEmpty(t, n0.Provider.CallerFile)
+
+		n3 := data.Nodes[3]
+		assert.Equal(t, "*autowire.Struct1_OK", n3.Type)
+		assert.Equal(t, "github.com/tiendc/autowire", n3.PkgPath)
+		assert.Equal(t, ProviderKindStruct, n3.Provider.Kind)
+		assert.Equal(t, "*autowire.Struct8_OK", n3.Provider.Name)
+		assert.Empty(t, n3.Provider.File)
+
+		assert.Equal(t, []GraphEdgeData{
+			{From: "n0", To: "n1", Kind: DependencyRequired},
+			{From: "n0", To: "n2", Kind: DependencyRequired},
+			{From: "n0", To: "n3", Kind: DependencyRequired},
+		}, data.Edges)
+	})
+}
+
+func TestContainerExportGraph(t *testing.T) {
+	t.Run("JSON round trip", func(t *testing.T) {
+		// S1 -> S2 -> S4 -> S1, S2 -> S5 (missing)
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK, NewSrv4_OK_With_Need_Srv1, &struct1_OK})
+		assert.Nil(t, err)
+		data := c.ExportGraph()
+
+		b, err := json.Marshal(data)
+		assert.Nil(t, err)
+		var data2 GraphData
+		err = json.Unmarshal(b, &data2)
+		assert.Nil(t, err)
+		assert.Equal(t, data, data2)
+		b2, err := json.Marshal(data2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(b), string(b2))
+		assert.Equal(t, data.DOT(), data2.DOT())
+		assert.Equal(t, data.Mermaid(), data2.Mermaid())
+	})
+
+	t.Run("JSON schema", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK})
+		assert.Nil(t, err)
+		data := c.ExportGraph(typeFor[Service1]())
+		data.Nodes[0].Provider.File = "data_test.go"
+		data.Nodes[0].Provider.Line = 1
+		data.Nodes[0].Provider.CallerFile = "graph_export_test.go"
+		data.Nodes[0].Provider.CallerLine = 2
+		data.Nodes[1].Provider = nil
+
+		b, err := json.Marshal(data)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"nodes": [
+				{"id": "n0", "type": "autowire.Service1", "pkgPath": "github.com/tiendc/autowire",
+					"provider": {"kind": "func", "name": "github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3",
+						"file": "data_test.go", "line": 1, "callerFile": "graph_export_test.go", "callerLine": 2}},
+				{"id": "n1", "type": "autowire.Service2", "pkgPath": "github.com/tiendc/autowire"},
+				{"id": "n2", "type": "autowire.Service3", "pkgPath": "github.com/tiendc/autowire", "missing": true}
+			],
+			"edges": [
+				{"from": "n0", "to": "n1", "kind": "required"},
+				{"from": "n0", "to": "n2", "kind": "required"}
+			]
+		}`, string(b))
+	})
+
+	t.Run("Package path of composite types", func(t *testing.T) {
+		assert.Equal(t, "", typePkgPath(typeFor[[]int]()))
+		assert.Equal(t, "", typePkgPath(typeFor[func()]()))
+		assert.Equal(t, "github.com/tiendc/autowire", typePkgPath(typeFor[map[string]*Struct1_OK]()))
+		assert.Equal(t, "context", typePkgPath(typeFor[[]context.Context]()))
+	})
+}
@@ -0,0 +1,72 @@
+package autowire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mermaid returns the dependency graph in Mermaid flowchart syntax.
+// Shared dependencies are rendered as a single node, missing and circular dependencies are highlighted.
+func (g DependencyGraph) Mermaid() string {
+	return g.Export().Mermaid()
+}
+
+// Mermaid returns the graph in Mermaid flowchart syntax
+func (g GraphData) Mermaid() string {
+	var sb strings.Builder
+	_ = g.WriteMermaid(&sb)
+	return sb.String()
+}
+
+// WriteMermaid writes the graph in Mermaid flowchart syntax
+func (g GraphData) WriteMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("flowchart TD\n")
+	var missingIDs, circularIDs []string
+	for i := range g.Nodes {
+		node := &g.Nodes[i]
+		label := node.Type
+		switch {
+		case node.Missing:
+			label += "\n(missing)"
+			missingIDs = append(missingIDs, node.ID)
+		case node.Circular:
+			label += "\n" + node.providerName()
+			circularIDs = append(circularIDs, node.ID)
+		case node.Provider == nil: // context types are passed via BuildWithCtx
+		default:
+			label += "\n" + node.providerName()
+		}
+		_, _ = fmt.Fprintf(bw, "\t%s[%s]\n", node.ID, mermaidQuote(label))
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		switch {
+		case edge.Circular:
+			arrow = "==>"
+		case edge.Kind == DependencyContext:
+			arrow = "-.->"
+		}
+		_, _ = fmt.Fprintf(bw, "\t%s %s %s\n", edge.From, arrow, edge.To)
+	}
+	if len(missingIDs) > 0 {
+		_, _ = bw.WriteString("\tclassDef missing stroke:#f00,color:#f00,stroke-dasharray:5 5\n")
+		_, _ = fmt.Fprintf(bw, "\tclass %s missing\n", strings.Join(missingIDs, ","))
+	}
+	if len(circularIDs) > 0 {
+		_, _ = bw.WriteString("\tclassDef circular stroke:#f90,stroke-width:3px\n")
+		_, _ = fmt.Fprintf(bw, "\tclass %s circular\n", strings.Join(circularIDs, ","))
+	}
+	return bw.Flush()
+}
+
+// mermaidQuote quotes a string as a Mermaid node label
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "<", "#lt;")
+	s = strings.ReplaceAll(s, ">", "#gt;")
+	s = strings.ReplaceAll(s, "\n", "<br/>")
+	return `"` + s + `"`
+}
@@ -0,0 +1,46 @@
+package autowire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyGraphMermaid(t *testing.T) {
+	t.Run("Shared dependencies are deduplicated", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv5_OK})
+		assert.Nil(t, err)
+		dg, err := Resolve[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, "flowchart TD\n"+
+			"\tn0[\"autowire.Service1<br/>github.com/tiendc/autowire.NewSrv1_OK_With_Need_Srv2_Srv3\"]\n"+
+			"\tn1[\"autowire.Service2<br/>github.com/tiendc/autowire.NewSrv2_OK_With_Need_Srv4_Srv5\"]\n"+
+			"\tn2[\"autowire.Service4<br/>github.com/tiendc/autowire.NewSrv4_OK\"]\n"+
+			"\tn3[\"autowire.Service5<br/>github.com/tiendc/autowire.NewSrv5_OK\"]\n"+
+			"\tn4[\"autowire.Service3<br/>github.com/tiendc/autowire.NewSrv3_OK_With_Need_Srv4\"]\n"+
+			"\tn0 --> n1\n"+
+			"\tn1 --> n2\n"+
+			"\tn1 --> n3\n"+
+			"\tn0 --> n4\n"+
+			"\tn4 --> n2\n", dg.Mermaid())
+	})
+}
+
+func TestGraphDataMermaid(t *testing.T) {
+	t.Run("Missing and circular dependencies are highlighted", func(t *testing.T) {
+		// S1 -> S2 -> S4 -> S1, S2 -> S5 (missing)
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK, NewSrv4_OK_With_Need_Srv1})
+		assert.Nil(t, err)
+		out := c.ExportGraph(typeFor[Service1]()).Mermaid()
+		assert.Contains(t, out, "\tn3[\"autowire.Service5<br/>(missing)\"]\n")
+		assert.Contains(t, out, "\tn2 ==> n0\n")
+		assert.Contains(t, out, "\tclass n3 missing\n")
+		assert.Contains(t, out, "\tclass n0 circular\n")
+	})
+
+	t.Run("Quote special characters", func(t *testing.T) {
+		assert.Equal(t, `"chan#lt;- #quot;a#quot;<br/>b"`, mermaidQuote("chan<- \"a\"\nb"))
+	})
+}
@@ -0,0 +1,113 @@
+package autowire
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerGraph(t *testing.T) {
+	t.Run("Queries", func(t *testing.T) {
+		// S1 -> (S2, S3), S2 -> (S4, S5), S3 -> S4
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv3_OK_With_Need_Srv4, NewSrv4_OK, NewSrv5_OK})
+		assert.Nil(t, err)
+		g := c.Graph()
+		s1, s2, s3, s4, s5 := typeFor[Service1](), typeFor[Service2](), typeFor[Service3](),
+			typeFor[Service4](), typeFor[Service5]()
+
+		assert.Equal(t, []reflect.Type{s1, s2, s3, s4, s5}, g.Types())
+		assert.Nil(t, g.Missing())
+		assert.Equal(t, []reflect.Type{s1}, g.Roots())
+		assert.Equal(t, []reflect.Type{s4, s5}, g.Leaves())
+		assert.Equal(t, "github.com/tiendc/autowire.NewSrv4_OK", g.ProviderOf(s4).Info().Name)
+		assert.Nil(t, g.ProviderOf(typeFor[int]()))
+
+		assert.Equal(t, []reflect.Type{s2, s3}, g.DependenciesOf(s1))
+		assert.Equal(t, []reflect.Type{s2, s3, s4, s5}, g.AllDependenciesOf(s1))
+		assert.Equal(t, []reflect.Type{s2, s3}, g.DependentsOf(s4))
+		assert.Equal(t, []reflect.Type{s1, s2, s3}, g.AllDependentsOf(s4))
+		assert.Nil(t, g.DependentsOf(typeFor[int]()))
+		assert.Nil(t, g.AllDependentsOf(typeFor[int]()))
+
+		order, err := g.TopologicalOrder()
+		assert.Nil(t, err)
+		assert.Equal(t, []reflect.Type{s4, s5, s2, s3, s1}, order)
+	})
+
+	t.Run("Missing types", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK})
+		assert.Nil(t, err)
+		g := c.Graph()
+		assert.Equal(t, []reflect.Type{typeFor[Service3]()}, g.Missing())
+		assert.Equal(t, []reflect.Type{typeFor[Service1]()}, g.DependentsOf(typeFor[Service3]()))
+		order, err := g.TopologicalOrder()
+		assert.Nil(t, err)
+		assert.Equal(t, []reflect.Type{typeFor[Service2](), typeFor[Service1]()}, order)
+	})
+
+	t.Run("Circular dependency", func(t *testing.T) {
+		// S1 -> S2 -> S4 -> S1, S3 -> S4
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5,
+			NewSrv5_OK, NewSrv3_OK_With_Need_Srv4, NewSrv4_OK_With_Need_Srv1})
+		assert.Nil(t, err)
+		g := c.Graph()
+		assert.Nil(t, g.Roots())
+		assert.Equal(t, []reflect.Type{typeFor[Service2](), typeFor[Service3](), typeFor[Service4]()},
+			g.AllDependentsOf(typeFor[Service1]()))
+		_, err = g.TopologicalOrder()
+		assert.ErrorIs(t, err, ErrCircularDependency)
+		assert.Contains(t, err.Error(),
+			"ErrCircularDependency: circular dependency detected at type 'autowire.Service1'")
+	})
+
+	t.Run("Field type of a struct provider replaced", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			c, err := NewContainer([]any{&struct1_OK, NewSrv4_OK})
+			assert.Nil(t, err)
+			sliceProv := MustNewProvider(func(s4 Service4) []int { return nil })
+			assert.Nil(t, c.Replace(sliceProv))
+			strProv := MustNewProvider(func(s4 Service4) string { return "" })
+			c.ProviderSet().Overwrite(strProv)
+
+			g := c.Graph()
+			assert.Same(t, sliceProv, g.ProviderOf(typeFor[[]int]()))
+			assert.Equal(t, []reflect.Type{typeFor[Service4]()}, g.DependenciesOf(typeFor[[]int]()))
+			assert.Same(t, strProv, g.ProviderOf(typeFor[string]()))
+			assert.Equal(t, []reflect.Type{typeFor[Service4]()}, g.DependenciesOf(typeFor[string]()))
+			assert.Equal(t, ProviderKindStruct, g.ProviderOf(typeFor[int]()).Info().Kind)
+			assert.Equal(t, []reflect.Type{typeFor[[]int](), typeFor[string]()}, g.DependentsOf(typeFor[Service4]()))
+			// The struct provider is not used by its replaced field type
+			unused := c.UnusedProviders(typeFor[[]int]())
+			assert.Equal(t, 2, len(unused))
+			assert.Equal(t, ProviderKindStruct, unused[0].Info().Kind)
+		}
+	})
+
+	t.Run("Context is not missing", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Ctx})
+		assert.Nil(t, err)
+		g := c.Graph()
+		assert.Nil(t, g.Missing())
+		assert.Equal(t, []reflect.Type{typeFor[context.Context]()}, g.DependenciesOf(typeFor[Service1]()))
+		data := g.Export()
+		assert.Equal(t, 2, len(data.Nodes))
+		assert.False(t, data.Nodes[1].Missing)
+		assert.Equal(t, DependencyContext, data.Edges[0].Kind)
+		assert.Contains(t, data.DOT(), "\tn1 [label=\"context.Context\", style=\"rounded\"];\n")
+		assert.Contains(t, data.DOT(), "\tn0 -> n1 [style=\"dashed\"];\n")
+		assert.Contains(t, data.Mermaid(), "\tn0 -.-> n1\n")
+	})
+
+	t.Run("Export specific types", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK, NewSrv4_OK})
+		assert.Nil(t, err)
+		data := c.Graph().Export(typeFor[Service1]())
+		assert.Equal(t, 3, len(data.Nodes))
+		assert.Equal(t, 2, len(data.Edges))
+		data = c.Graph().Export()
+		assert.Equal(t, 4, len(data.Nodes))
+	})
+}
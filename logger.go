@@ -0,0 +1,47 @@
+package autowire
+
+import (
+	"reflect"
+)
+
+// diagnosticLogger receives diagnostic events of a container. Besides build events of Observer,
+// it is notified of provider registration, cache misses and overwrites.
+type diagnosticLogger interface {
+	Observer
+
+	// onRegister is called for each type provided by a provider after the providers are parsed,
+	// when the container is created or providers are added by Container.Provide
+	onRegister(targetType reflect.Type, provider Provider)
+
+	// onCacheMiss is called when an object of the type is not found in the cache in shared mode
+	onCacheMiss(targetType reflect.Type)
+
+	// onOverwrite is called when a provider is overwritten for the current build
+	onOverwrite(targetType reflect.Type, provider Provider)
+}
+
+// logRegistration notifies the logger of every provider of the container
+func (c *container) logRegistration(providers []Provider) {
+	if c.logger == nil {
+		return
+	}
+	for _, provider := range uniqueProviders(providers) {
+		targetTypes := append([]reflect.Type{}, provider.TargetTypes()...)
+		sortTypes(targetTypes)
+		for _, targetType := range targetTypes {
+			c.logger.onRegister(targetType, provider)
+		}
+	}
+}
+
+func (ctx *Context) logCacheMiss(targetType reflect.Type) {
+	if ctx.logger != nil {
+		ctx.logger.onCacheMiss(targetType)
+	}
+}
+
+func (ctx *Context) logOverwrite(targetType reflect.Type, provider Provider) {
+	if ctx.logger != nil {
+		ctx.logger.onOverwrite(targetType, provider)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build go1.21
+
+package autowire
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// WithLogger config option for logging diagnostic events of a container. Provider registration,
+// cache hits and misses, overwrites and builds are logged at debug level, and failed builds are
+// logged at warn level. Log records have the attributes `type`, `provider` and `duration`.
+// Providers are logged once parsed and the container is configured, which happens in NewContainer
+// and Container.Provide. A nil logger disables logging.
+func WithLogger(logger *slog.Logger) ContainerConfigOption {
+	return func(c Container) {
+		if logger == nil {
+			return
+		}
+		c.setLogger(&slogLogger{logger: logger})
+	}
+}
+
+// slogLogger an implementation of diagnosticLogger interface using log/slog
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// OnBuildStart implementation of Observer interface
+func (l *slogLogger) OnBuildStart(targetType reflect.Type, provider Provider) {
+	l.log(slog.LevelDebug, "autowire: build started",
+		slog.String("type", targetType.String()), slog.String("provider", provider.Info().String()))
+}
+
+// OnBuildEnd implementation of Observer interface
+func (l *slogLogger) OnBuildEnd(targetType reflect.Type, provider Provider, duration time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String("type", targetType.String()),
+		slog.String("provider", provider.Info().String()),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		l.log(slog.LevelWarn, "autowire: build failed", append(attrs, slog.String("error", err.Error()))...)
+		return
+	}
+	l.log(slog.LevelDebug, "autowire: build finished", attrs...)
+}
+
+// OnCacheHit implementation of Observer interface
+func (l *slogLogger) OnCacheHit(targetType reflect.Type) {
+	l.log(slog.LevelDebug, "autowire: cache hit", slog.String("type", targetType.String()))
+}
+
+// OnBuildRetry implementation of Observer interface
+func (l *slogLogger) OnBuildRetry(targetType reflect.Type, provider Provider, attempt int, err error) {
+	l.log(slog.LevelDebug, "autowire: build retry",
+		slog.String("type", targetType.String()), slog.String("provider", provider.Info().String()),
+		slog.Int("attempt", attempt), slog.String("error", err.Error()))
+}
+
+// onRegister implementation of diagnosticLogger interface
+func (l *slogLogger) onRegister(targetType reflect.Type, provider Provider) {
+	l.log(slog.LevelDebug, "autowire: provider registered",
+		slog.String("type", targetType.String()), slog.String("provider", provider.Info().String()))
+}
+
+// onCacheMiss implementation of diagnosticLogger interface
+func (l *slogLogger) onCacheMiss(targetType reflect.Type) {
+	l.log(slog.LevelDebug, "autowire: cache miss", slog.String("type", targetType.String()))
+}
+
+// onOverwrite implementation of diagnosticLogger interface
+func (l *slogLogger) onOverwrite(targetType reflect.Type, provider Provider) {
+	l.log(slog.LevelDebug, "autowire: provider overwritten",
+		slog.String("type", targetType.String()), slog.String("provider", provider.Info().String()))
+}
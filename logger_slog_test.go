@@ -0,0 +1,100 @@
+//go:build go1.21
+
+package autowire
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := map[string]any{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func logMessages(records []map[string]any) []string {
+	msgs := make([]string, 0, len(records))
+	for _, record := range records {
+		msgs = append(msgs, record["msg"].(string)+" "+record["type"].(string))
+	}
+	return msgs
+}
+
+func TestWithLogger(t *testing.T) {
+	newLogger := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		c, err := NewContainer([]any{NewSrv2_OK, NewSrv3_OK_With_Need_Srv4, NewSrv4_OK}, WithLogger(newLogger(buf)))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"autowire: provider registered autowire.Service2",
+			"autowire: provider registered autowire.Service3",
+			"autowire: provider registered autowire.Service4",
+		}, logMessages(parseLogRecords(t, buf)))
+
+		buf.Reset()
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		_, err = Build[Service3](c)
+		assert.Nil(t, err)
+		records := parseLogRecords(t, buf)
+		assert.Equal(t, []string{
+			"autowire: cache miss autowire.Service3",
+			"autowire: build started autowire.Service3",
+			"autowire: cache miss autowire.Service4",
+			"autowire: build started autowire.Service4",
+			"autowire: build finished autowire.Service4",
+			"autowire: build finished autowire.Service3",
+			"autowire: cache hit autowire.Service3",
+		}, logMessages(records))
+		assert.Equal(t, "DEBUG", records[1]["level"])
+		assert.Contains(t, records[1]["provider"], "NewSrv3_OK_With_Need_Srv4")
+		assert.NotNil(t, records[5]["duration"])
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		c, err := NewContainer([]any{NewSrv3_OK_With_Need_Srv4, NewSrv4_OK})
+		assert.Nil(t, err)
+		c.(*container).setLogger(&slogLogger{logger: newLogger(buf)})
+		_, err = Build[Service3](c, NonSharedMode(), ProviderOverwrite[Service4](&service4{}))
+		assert.Nil(t, err)
+		records := parseLogRecords(t, buf)
+		assert.Equal(t, "autowire: provider overwritten autowire.Service4", logMessages(records)[0])
+		assert.Equal(t, "autowire.Service4", records[0]["provider"])
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK}, WithLogger(newLogger(buf)))
+		assert.Nil(t, err)
+		buf.Reset()
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+		records := parseLogRecords(t, buf)
+		last := records[len(records)-1]
+		assert.Equal(t, "autowire: build failed", last["msg"])
+		assert.Equal(t, "WARN", last["level"])
+		assert.Contains(t, last["error"], "errTest1")
+	})
+
+	t.Run("Nil logger", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK}, WithLogger(nil))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package autowire
+
+import (
+	"reflect"
+)
+
+// objectCache an immutable map of cached objects. Adding objects creates a layer on top of the cache
+// instead of copying it. A layer is merged into its parent when it is not smaller than the parent,
+// so a cache of n objects has at most log(n) layers, and each object is copied log(n) times at most.
+// Layers are shared between caches, so they must never be modified after creation.
+type objectCache struct {
+	parent  *objectCache
+	objects map[reflect.Type]builtObject
+	size    int
+}
+
+// newObjectCache creates a cache of the objects, the map must not be modified afterward
+func newObjectCache(objects map[reflect.Type]builtObject) *objectCache {
+	return &objectCache{objects: objects, size: len(objects)}
+}
+
+// get gets the object of the type
+func (oc *objectCache) get(typ reflect.Type) (builtObject, bool) {
+	for layer := oc; layer != nil; layer = layer.parent {
+		if obj, exist := layer.objects[typ]; exist {
+			return obj, true
+		}
+	}
+	return builtObject{}, false
+}
+
+// all returns a copy of every object of the cache
+func (oc *objectCache) all() map[reflect.Type]builtObject {
+	ret := make(map[reflect.Type]builtObject, oc.size)
+	for layer := oc; layer != nil; layer = layer.parent {
+		for typ, obj := range layer.objects {
+			if _, exist := ret[typ]; !exist {
+				ret[typ] = obj
+			}
+		}
+	}
+	return ret
+}
+
+// with returns a cache of the objects added to this cache. The objects must be of types not in this cache.
+func (oc *objectCache) with(objects map[reflect.Type]builtObject) *objectCache {
+	layer := &objectCache{parent: oc, objects: objects, size: oc.size + len(objects)}
+	for layer.parent != nil && len(layer.objects) >= len(layer.parent.objects) {
+		parent := layer.parent
+		merged := make(map[reflect.Type]builtObject, len(parent.objects)+len(layer.objects))
+		for typ, obj := range parent.objects {
+			merged[typ] = obj
+		}
+		for typ, obj := range layer.objects {
+			merged[typ] = obj
+		}
+		layer = &objectCache{parent: parent.parent, objects: merged, size: layer.size}
+	}
+	return layer
+}
@@ -0,0 +1,44 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_objectCache(t *testing.T) {
+	t.Run("Layers", func(t *testing.T) {
+		types := []reflect.Type{typeFor[Service1](), typeFor[Service2](), typeFor[Service3](), typeFor[Service4](),
+			typeFor[Service5](), typeFor[int](), typeFor[string]()}
+		cache := newObjectCache(nil)
+		caches := []*objectCache{cache}
+		for i, typ := range types {
+			cache = cache.with(map[reflect.Type]builtObject{typ: {value: reflect.ValueOf(i), seq: uint64(i)}})
+			caches = append(caches, cache)
+		}
+
+		all := cache.all()
+		assert.Equal(t, len(types), len(all))
+		for i, typ := range types {
+			obj, exist := cache.get(typ)
+			assert.True(t, exist)
+			assert.Equal(t, uint64(i), obj.seq)
+			assert.Equal(t, uint64(i), all[typ].seq)
+		}
+		_, exist := cache.get(typeFor[bool]())
+		assert.False(t, exist)
+
+		// Earlier caches are not modified
+		for i, c := range caches {
+			assert.Equal(t, i, len(c.all()))
+		}
+
+		// Layers are merged, 7 objects are in 3 layers at most
+		layers := 0
+		for layer := cache; layer != nil; layer = layer.parent {
+			layers++
+		}
+		assert.LessOrEqual(t, layers, 3)
+	})
+}
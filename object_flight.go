@@ -0,0 +1,157 @@
+package autowire
+
+import (
+	"reflect"
+	"sync"
+)
+
+// flightGroup makes concurrent builds of a container share the construction of objects in shared mode.
+// The first build which needs an object of a type constructs it, the others wait for it and use the
+// same object, so every object is created once. Flights are kept for the generation of the container,
+// so builds started before the object is cached still find it.
+type flightGroup struct {
+	mu         sync.Mutex
+	generation uint64
+	flights    map[reflect.Type]*flight
+}
+
+// flight construction of an object of a type by a build
+type flight struct {
+	done chan struct{}
+	// owner is the context constructing the object, it is nil once the construction ends
+	owner *Context
+	obj   builtObject
+	// users number of unfinished builds using the object
+	users int
+	// committed is set when a build using the object succeeds, the object is owned by the container
+	// or by the caller of the build then
+	committed bool
+}
+
+// joinFlight returns the object of the type when a concurrent build constructs or has constructed it.
+// Otherwise, the context is registered as the one constructing it, and landFlight must be called when
+// the construction ends.
+func (ctx *Context) joinFlight(targetType reflect.Type) (value reflect.Value, exist bool, err error) {
+	g := ctx.flights
+	if g == nil {
+		return value, false, nil
+	}
+	if _, affected := ctx.affectedTypes[targetType]; affected {
+		return value, false, nil
+	}
+
+	for {
+		g.mu.Lock()
+		if ctx.generation < g.generation {
+			// Objects of outdated builds are not cached, they are constructed by the builds themselves
+			g.mu.Unlock()
+			return value, false, nil
+		}
+		if g.flights == nil || ctx.generation > g.generation {
+			g.generation = ctx.generation
+			g.flights = map[reflect.Type]*flight{}
+		}
+
+		f := g.flights[targetType]
+		if f == nil {
+			g.flights[targetType] = &flight{done: make(chan struct{}), owner: ctx, users: 1}
+			ctx.usedFlights[targetType] = g.flights[targetType]
+			g.mu.Unlock()
+			return value, false, nil
+		}
+		if f.owner == nil {
+			f.users++
+			ctx.usedFlights[targetType] = f
+			g.mu.Unlock()
+			ctx.addObject(targetType, f.obj)
+			return f.obj.value, true, nil
+		}
+		if g.waitsFor(f, ctx) {
+			// Waiting would never end, the object is constructed and the circular dependency is reported
+			g.mu.Unlock()
+			return value, false, nil
+		}
+		ctx.waitingFlight = f
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.baseContext().Done():
+		}
+		g.mu.Lock()
+		ctx.waitingFlight = nil
+		g.mu.Unlock()
+		if err = ctx.checkCanceled(targetType); err != nil {
+			return value, false, err
+		}
+	}
+}
+
+// waitsFor returns true when the owner of the flight waits for the context, directly or via other builds
+func (g *flightGroup) waitsFor(f *flight, ctx *Context) bool {
+	for owner := f.owner; owner != nil; {
+		if owner == ctx {
+			return true
+		}
+		if owner.waitingFlight == nil {
+			return false
+		}
+		owner = owner.waitingFlight.owner
+	}
+	return false
+}
+
+// landFlight ends the construction of the type registered by joinFlight. The construction succeeds
+// when the object is stored in the context, otherwise waiting builds construct the object themselves.
+func (ctx *Context) landFlight(targetType reflect.Type) {
+	g := ctx.flights
+	if g == nil {
+		return
+	}
+	obj, exist := ctx.builtObject(targetType)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f := ctx.usedFlights[targetType]
+	if f == nil || f.owner != ctx {
+		return
+	}
+	f.owner = nil
+	if exist {
+		f.obj = obj
+	} else {
+		delete(ctx.usedFlights, targetType)
+		if g.flights[targetType] == f {
+			delete(g.flights, targetType)
+		}
+	}
+	close(f.done)
+}
+
+// leaveFlights ends the use of objects of flights when the build ends. This returns the types of objects
+// which are still used by other builds or are committed, they must not be closed when the build fails.
+func (ctx *Context) leaveFlights(succeeded bool) map[reflect.Type]struct{} {
+	g := ctx.flights
+	if g == nil || len(ctx.usedFlights) == 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	inUse := make(map[reflect.Type]struct{}, len(ctx.usedFlights))
+	for typ, f := range ctx.usedFlights {
+		f.users--
+		if succeeded {
+			f.committed = true
+		}
+		if f.users > 0 || f.committed {
+			inUse[typ] = struct{}{}
+			continue
+		}
+		// Nobody uses the object, it is closed by the caller. It will be constructed again when needed.
+		if g.flights[typ] == f {
+			delete(g.flights, typ)
+		}
+	}
+	return inUse
+}
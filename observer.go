@@ -0,0 +1,110 @@
+package autowire
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Observer receives events of building objects within a container, which is useful for logging,
+// collecting metrics, and tracing. Observer methods can be called concurrently in parallel mode.
+type Observer interface {
+	// OnBuildStart is called when a provider starts building an object of the type
+	OnBuildStart(targetType reflect.Type, provider Provider)
+
+	// OnBuildEnd is called when a provider finishes building an object of the type.
+	// The duration includes the time of building the dependencies of the object.
+	// When the provider panics, the error describes the panic, and the panic continues afterward.
+	OnBuildEnd(targetType reflect.Type, provider Provider, duration time.Duration, err error)
+
+	// OnCacheHit is called when an object of the type is taken from the cache in shared mode
+	OnCacheHit(targetType reflect.Type)
+
+	// OnBuildRetry is called when a provider function failed and is going to be called again
+	// (see WithRetry). The attempt is the number of the failed attempt starting from 1.
+	OnBuildRetry(targetType reflect.Type, provider Provider, attempt int, err error)
+}
+
+// ContextObserver an Observer which passes values to providers via context.Context, such as tracing spans.
+// For this kind of observer, StartBuild is called instead of OnBuildStart and OnBuildEnd.
+type ContextObserver interface {
+	Observer
+
+	// StartBuild is called when a provider starts building an object of the type. The returned context
+	// is passed to the provider and its dependencies, and the returned function is called when the
+	// build ends. The specified context is context.Background() when no context is passed via BuildWithCtx.
+	StartBuild(ctx context.Context, targetType reflect.Type, provider Provider) (context.Context, func(err error))
+}
+
+// NoopObserver an Observer which does nothing. It can be embedded in an observer implementation
+// which is only interested in some of the events.
+type NoopObserver struct{}
+
+// OnBuildStart implementation of Observer interface
+func (NoopObserver) OnBuildStart(reflect.Type, Provider) {}
+
+// OnBuildEnd implementation of Observer interface
+func (NoopObserver) OnBuildEnd(reflect.Type, Provider, time.Duration, error) {}
+
+// OnCacheHit implementation of Observer interface
+func (NoopObserver) OnCacheHit(reflect.Type) {}
+
+// OnBuildRetry implementation of Observer interface
+func (NoopObserver) OnBuildRetry(reflect.Type, Provider, int, error) {}
+
+// WithObserver config option for adding an observer to a container.
+// Multiple observers can be added, they are called in the order of adding.
+func WithObserver(observer Observer) ContainerConfigOption {
+	return func(c Container) {
+		c.addObserver(observer)
+	}
+}
+
+// observeBuildStart notifies observers that a provider starts building and returns
+// a function to notify them when the build ends
+func (ctx *Context) observeBuildStart(targetType reflect.Type, provider Provider) func(error) {
+	if len(ctx.observers) == 0 {
+		return func(error) {}
+	}
+	parentCtx, parentObserverCtx := ctx.context, ctx.observerContext
+	var endFuncs []func(error)
+	for _, observer := range ctx.observers {
+		if ctxObserver, ok := observer.(ContextObserver); ok {
+			childCtx, end := ctxObserver.StartBuild(ctx.baseContext(), targetType, provider)
+			if ctx.context != nil {
+				ctx.context = childCtx
+			} else {
+				ctx.observerContext = childCtx
+			}
+			endFuncs = append(endFuncs, end)
+			continue
+		}
+		observer.OnBuildStart(targetType, provider)
+	}
+	start := time.Now()
+	return func(err error) {
+		duration := time.Since(start)
+		for _, observer := range ctx.observers {
+			if _, ok := observer.(ContextObserver); ok {
+				continue
+			}
+			observer.OnBuildEnd(targetType, provider, duration, err)
+		}
+		for i := len(endFuncs) - 1; i >= 0; i-- {
+			endFuncs[i](err)
+		}
+		ctx.context, ctx.observerContext = parentCtx, parentObserverCtx
+	}
+}
+
+func (ctx *Context) observeCacheHit(targetType reflect.Type) {
+	for _, observer := range ctx.observers {
+		observer.OnCacheHit(targetType)
+	}
+}
+
+func (ctx *Context) observeBuildRetry(targetType reflect.Type, provider Provider, attempt int, err error) {
+	for _, observer := range ctx.observers {
+		observer.OnBuildRetry(targetType, provider, attempt, err)
+	}
+}
@@ -0,0 +1,160 @@
+package autowire
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	NoopObserver
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) OnBuildStart(targetType reflect.Type, provider Provider) {
+	o.record(fmt.Sprintf("start %v", targetType))
+}
+
+func (o *recordingObserver) OnBuildEnd(targetType reflect.Type, provider Provider, d time.Duration, err error) {
+	o.record(fmt.Sprintf("end %v %v", targetType, err != nil))
+}
+
+func (o *recordingObserver) OnCacheHit(targetType reflect.Type) {
+	o.record(fmt.Sprintf("hit %v", targetType))
+}
+
+func (o *recordingObserver) OnBuildRetry(targetType reflect.Type, provider Provider, attempt int, err error) {
+	o.record(fmt.Sprintf("retry %v %d %v", targetType, attempt, err))
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("Build events", func(t *testing.T) {
+		o := &recordingObserver{}
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK, &struct1_OK},
+			WithObserver(o), WithObserver(NoopObserver{}))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		_, err = Build[Service2](c)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"start autowire.Service1",
+			"start autowire.Service2",
+			"end autowire.Service2 false",
+			"start autowire.Service3",
+			"end autowire.Service3 false",
+			"start []int",
+			"end []int false",
+			"end autowire.Service1 false",
+			"hit autowire.Service2",
+		}, o.events)
+	})
+
+	t.Run("Failure and retry events", func(t *testing.T) {
+		o := &recordingObserver{}
+		p1 := MustNewProvider(NewSrv1_Fail_With_Err, WithRetry(1, nil))
+		c, err := NewContainer([]any{p1}, WithObserver(o))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+		assert.Equal(t, []string{
+			"start autowire.Service1",
+			"retry autowire.Service1 1 errTest1",
+			"end autowire.Service1 true",
+		}, o.events)
+	})
+
+	t.Run("Panic is reported as an error", func(t *testing.T) {
+		o := &recordingObserver{}
+		c, err := NewContainer([]any{func() Service1 { panic("s1 panics") }}, WithObserver(o))
+		assert.Nil(t, err)
+		assert.PanicsWithValue(t, "s1 panics", func() {
+			_, _ = Build[Service1](c)
+		})
+		assert.Equal(t, []string{
+			"start autowire.Service1",
+			"end autowire.Service1 true",
+		}, o.events)
+	})
+
+	t.Run("Duration is reported", func(t *testing.T) {
+		var duration time.Duration
+		o := &durationObserver{fn: func(d time.Duration) { duration = d }}
+		c, err := NewContainer([]any{func() Service1 {
+			time.Sleep(10 * time.Millisecond)
+			return &service1{}
+		}}, WithObserver(o))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		assert.True(t, duration >= 10*time.Millisecond)
+	})
+}
+
+func TestContextObserver(t *testing.T) {
+	t.Run("Context is nested", func(t *testing.T) {
+		o := &pathObserver{}
+		c, err := NewContainer([]any{
+			func(s2 Service2) Service1 { return &service1{serviceBase{initArgs: []any{s2}}} },
+			func(ctx context.Context) Service2 {
+				return &service2{serviceBase{initArgs: []any{ctx.Value(ctxKey{})}}}
+			},
+		}, WithObserver(o))
+		assert.Nil(t, err)
+		s1, err := BuildWithCtx[Service1](context.Background(), c)
+		assert.Nil(t, err)
+		s2 := s1.(*service1).initArgs[0].(*service2)
+		assert.Equal(t, "/autowire.Service1/autowire.Service2", s2.initArgs[0])
+		assert.Equal(t, []string{
+			"end /autowire.Service1/autowire.Service2 <nil>",
+			"end /autowire.Service1 <nil>",
+		}, o.events)
+	})
+
+	t.Run("Context is nested without passing context", func(t *testing.T) {
+		o := &pathObserver{}
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK}, WithObserver(o))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+		assert.Equal(t, 2, len(o.events))
+		assert.Equal(t, "end /autowire.Service1/autowire.Service2 <nil>", o.events[0])
+		assert.Contains(t, o.events[1], "end /autowire.Service1 errTest1")
+	})
+}
+
+type pathObserver struct {
+	NoopObserver
+	events []string
+}
+
+func (o *pathObserver) StartBuild(ctx context.Context, targetType reflect.Type, _ Provider) (
+	context.Context, func(error),
+) {
+	parent, _ := ctx.Value(ctxKey{}).(string)
+	path := parent + "/" + targetType.String()
+	return context.WithValue(ctx, ctxKey{}, path), func(err error) {
+		o.events = append(o.events, fmt.Sprintf("end %s %v", path, err))
+	}
+}
+
+type durationObserver struct {
+	NoopObserver
+	fn func(time.Duration)
+}
+
+func (o *durationObserver) OnBuildEnd(_ reflect.Type, _ Provider, d time.Duration, _ error) {
+	o.fn(d)
+}
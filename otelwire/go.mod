@@ -0,0 +1,27 @@
+module github.com/tiendc/autowire/otelwire
+
+go 1.20
+
+require (
+	github.com/stretchr/testify v1.9.0
+	github.com/tiendc/autowire v1.1.0
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// For local development only, the module is built against the working tree. Consumers of the module
+// ignore this directive and use the required version above, which is the first release of autowire
+// with ContextObserver and Provider.Info. Both modules are released together with the same version,
+// tagged as `vX.Y.Z` and `otelwire/vX.Y.Z`.
+replace github.com/tiendc/autowire => ../
@@ -0,0 +1,95 @@
+// Package otelwire provides OpenTelemetry tracing of building objects in autowire containers.
+//
+// A span is created for each provider invocation. Spans are nested according to the dependency
+// chain, and the span context is passed to providers which take context.Context:
+//
+//	container := autowire.MustNewContainer(providers, otelwire.WithTracing())
+//	service, err := autowire.BuildWithCtx[Service](ctx, container)
+package otelwire
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tiendc/autowire"
+)
+
+const (
+	instrumentationName = "github.com/tiendc/autowire/otelwire"
+
+	// AttrType attribute key of the type built by the provider
+	AttrType = attribute.Key("autowire.type")
+	// AttrProvider attribute key of the provider name
+	AttrProvider = attribute.Key("autowire.provider")
+	// AttrProviderKind attribute key of the provider kind
+	AttrProviderKind = attribute.Key("autowire.provider.kind")
+)
+
+// Option configuration setter for tracing
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+}
+
+// WithTracerProvider sets the tracer provider used to create spans.
+// The global tracer provider is used by default.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = tracerProvider
+	}
+}
+
+// observer an implementation of autowire.ContextObserver interface which creates spans
+type observer struct {
+	autowire.NoopObserver
+	tracer trace.Tracer
+}
+
+// NewObserver creates an observer which creates a span for each provider invocation
+func NewObserver(opts ...Option) autowire.ContextObserver {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	return &observer{
+		tracer: cfg.tracerProvider.Tracer(instrumentationName),
+	}
+}
+
+// WithTracing config option for tracing a container
+func WithTracing(opts ...Option) autowire.ContainerConfigOption {
+	return autowire.WithObserver(NewObserver(opts...))
+}
+
+// StartBuild implementation of autowire.ContextObserver interface
+func (o *observer) StartBuild(ctx context.Context, targetType reflect.Type, provider autowire.Provider) (
+	context.Context, func(err error),
+) {
+	info := provider.Info()
+	attrs := []attribute.KeyValue{
+		AttrType.String(targetType.String()),
+		AttrProvider.String(info.Name),
+		AttrProviderKind.String(string(info.Kind)),
+	}
+	if info.File != "" {
+		attrs = append(attrs, attribute.String("code.filepath", info.File), attribute.Int("code.lineno", info.Line))
+	}
+
+	ctx, span := o.tracer.Start(ctx, "autowire.Build "+targetType.String(), trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,103 @@
+package otelwire
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tiendc/autowire"
+)
+
+var errTest = errors.New("errTest")
+
+type Service1 struct {
+	Service2 *Service2
+}
+
+type Service2 struct {
+	SpanContext trace.SpanContext
+}
+
+func NewService1(s2 *Service2) *Service1 {
+	return &Service1{Service2: s2}
+}
+
+func NewService1Fail(*Service2) (*Service1, error) {
+	return nil, errTest
+}
+
+func NewService2(ctx context.Context) *Service2 {
+	return &Service2{SpanContext: trace.SpanContextFromContext(ctx)}
+}
+
+func newTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func TestWithTracing(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tp, exporter := newTracerProvider()
+		c, err := autowire.NewContainer([]any{NewService1, NewService2}, WithTracing(WithTracerProvider(tp)))
+		assert.Nil(t, err)
+
+		ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+		s1, err := autowire.BuildWithCtx[*Service1](ctx, c)
+		assert.Nil(t, err)
+		root.End()
+
+		spans := exporter.GetSpans()
+		assert.Equal(t, 3, len(spans))
+		s2Span, s1Span := spans[0], spans[1]
+		assert.Equal(t, "autowire.Build *otelwire.Service2", s2Span.Name)
+		assert.Equal(t, "autowire.Build *otelwire.Service1", s1Span.Name)
+		assert.Equal(t, root.SpanContext().SpanID(), s1Span.Parent.SpanID())
+		assert.Equal(t, s1Span.SpanContext.SpanID(), s2Span.Parent.SpanID())
+		assert.Equal(t, codes.Unset, s1Span.Status.Code)
+		assert.Contains(t, s1Span.Attributes, AttrType.String("*otelwire.Service1"))
+		assert.Contains(t, s1Span.Attributes, AttrProviderKind.String("func"))
+		assert.Contains(t, s1Span.Attributes, AttrProvider.String("github.com/tiendc/autowire/otelwire.NewService1"))
+
+		// Span context is passed to the provider
+		assert.Equal(t, s2Span.SpanContext.SpanID(), s1.Service2.SpanContext.SpanID())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		tp, exporter := newTracerProvider()
+		c, err := autowire.NewContainer([]any{NewService1Fail, NewService2}, WithTracing(WithTracerProvider(tp)))
+		assert.Nil(t, err)
+
+		_, err = autowire.BuildWithCtx[*Service1](context.Background(), c)
+		assert.ErrorIs(t, err, errTest)
+
+		spans := exporter.GetSpans()
+		assert.Equal(t, 2, len(spans))
+		s1Span := spans[1]
+		assert.Equal(t, codes.Error, s1Span.Status.Code)
+		assert.Equal(t, 1, len(s1Span.Events))
+		assert.Equal(t, "exception", s1Span.Events[0].Name)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("Without passing context", func(t *testing.T) {
+		tp, exporter := newTracerProvider()
+		c, err := autowire.NewContainer([]any{NewService1, func() *Service2 { return &Service2{} }},
+			WithTracing(WithTracerProvider(tp)))
+		assert.Nil(t, err)
+
+		_, err = autowire.Build[*Service1](c)
+		assert.Nil(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Equal(t, 2, len(spans))
+		assert.Equal(t, "autowire.Build *otelwire.Service2", spans[0].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.False(t, spans[1].Parent.IsValid())
+	})
+}
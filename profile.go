@@ -0,0 +1,189 @@
+package autowire
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Profile an Observer which records a tree of provider calls for each build, so that slow
+// providers can be found. Use it as an observer of a container:
+//
+//	profile := NewProfile()
+//	container := MustNewContainer(providers, WithObserver(profile))
+//
+// Calls are nested by the dependency chain, the current call is passed to dependencies via the context
+// of the build. In parallel mode, objects built concurrently are recorded as separate top-level calls.
+type Profile struct {
+	mu    sync.Mutex
+	roots []*ProfileCall
+}
+
+// ProfileCall a call of a provider recorded by Profile
+type ProfileCall struct {
+	Type     reflect.Type
+	Provider ProviderInfo
+	Start    time.Time
+	// Total is the duration of the call including the time of building dependencies
+	Total time.Duration
+	// Self is the duration of the call excluding the time of building dependencies
+	Self  time.Duration
+	Err   error
+	Calls []*ProfileCall
+}
+
+// profileCallKey context key of the current call recorded by a profile
+type profileCallKey struct {
+	profile *Profile
+}
+
+// NewProfile creates a new Profile
+func NewProfile() *Profile {
+	return &Profile{}
+}
+
+// StartBuild implementation of ContextObserver interface
+func (p *Profile) StartBuild(ctx context.Context, targetType reflect.Type, provider Provider) (
+	context.Context, func(err error),
+) {
+	call := &ProfileCall{
+		Type:     targetType,
+		Provider: provider.Info(),
+		Start:    time.Now(),
+	}
+	key := profileCallKey{profile: p}
+	parent, _ := ctx.Value(key).(*ProfileCall)
+
+	p.mu.Lock()
+	if parent == nil {
+		p.roots = append(p.roots, call)
+	} else {
+		parent.Calls = append(parent.Calls, call)
+	}
+	p.mu.Unlock()
+
+	return context.WithValue(ctx, key, call), func(err error) {
+		duration := time.Since(call.Start)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		call.Total = duration
+		call.Err = err
+		call.Self = duration
+		for _, child := range call.Calls {
+			call.Self -= child.Total
+		}
+	}
+}
+
+// OnBuildStart implementation of Observer interface, calls are recorded by StartBuild
+func (p *Profile) OnBuildStart(reflect.Type, Provider) {}
+
+// OnBuildEnd implementation of Observer interface, calls are recorded by StartBuild
+func (p *Profile) OnBuildEnd(reflect.Type, Provider, time.Duration, error) {}
+
+// OnCacheHit implementation of Observer interface
+func (p *Profile) OnCacheHit(reflect.Type) {}
+
+// OnBuildRetry implementation of Observer interface
+func (p *Profile) OnBuildRetry(reflect.Type, Provider, int, error) {}
+
+// Builds returns a copy of the recorded calls of top-level providers, one for each build
+func (p *Profile) Builds() []*ProfileCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	builds := make([]*ProfileCall, 0, len(p.roots))
+	for _, root := range p.roots {
+		builds = append(builds, root.clone())
+	}
+	return builds
+}
+
+// Reset clears the recorded calls
+func (p *Profile) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.roots = nil
+}
+
+// clone deep-copies the call and its nested calls
+func (call *ProfileCall) clone() *ProfileCall {
+	ret := *call
+	ret.Calls = make([]*ProfileCall, 0, len(call.Calls))
+	for _, child := range call.Calls {
+		ret.Calls = append(ret.Calls, child.clone())
+	}
+	return &ret
+}
+
+// WriteReport writes a text report of all recorded calls sorted by self time (slowest first)
+func (p *Profile) WriteReport(w io.Writer) error {
+	var calls []*ProfileCall
+	for _, root := range p.Builds() {
+		calls = appendCalls(calls, root)
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Self > calls[j].Self
+	})
+
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "%12s %12s  %s\n", "SELF", "TOTAL", "TYPE (PROVIDER)")
+	for _, call := range calls {
+		_, _ = fmt.Fprintf(bw, "%12v %12v  %v (%v)", call.Self, call.Total, call.Type, call.Provider)
+		if call.Err != nil {
+			_, _ = fmt.Fprintf(bw, " error: %v", call.Err)
+		}
+		_, _ = bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+func appendCalls(calls []*ProfileCall, call *ProfileCall) []*ProfileCall {
+	calls = append(calls, call)
+	for _, child := range call.Calls {
+		calls = appendCalls(calls, child)
+	}
+	return calls
+}
+
+// chromeTraceEvent a complete event of Chrome trace event format
+type chromeTraceEvent struct {
+	Name string            `json:"name"`
+	Cat  string            `json:"cat"`
+	Ph   string            `json:"ph"`
+	Ts   int64             `json:"ts"`
+	Dur  int64             `json:"dur"`
+	Pid  int               `json:"pid"`
+	Tid  int               `json:"tid"`
+	Args map[string]string `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes all recorded calls in Chrome trace event format, which can be
+// loaded in `chrome://tracing` or https://ui.perfetto.dev
+func (p *Profile) WriteChromeTrace(w io.Writer) error {
+	events := []chromeTraceEvent{}
+	for _, root := range p.Builds() {
+		for _, call := range appendCalls(nil, root) {
+			args := map[string]string{"provider": call.Provider.String()}
+			if call.Err != nil {
+				args["error"] = call.Err.Error()
+			}
+			events = append(events, chromeTraceEvent{
+				Name: call.Type.String(),
+				Cat:  "autowire",
+				Ph:   "X",
+				Ts:   call.Start.UnixNano() / int64(time.Microsecond),
+				Dur:  call.Total.Microseconds(),
+				Pid:  1,
+				Tid:  1,
+				Args: args,
+			})
+		}
+	}
+	return json.NewEncoder(w).Encode(map[string]any{"traceEvents": events})
+}
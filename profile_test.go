@@ -0,0 +1,134 @@
+package autowire
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	t.Run("Call tree", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK, NewSrv3_OK, &struct1_OK},
+			WithObserver(profile))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		_, err = Build[Service2](c) // cache hit is not recorded
+		assert.Nil(t, err)
+
+		builds := profile.Builds()
+		assert.Equal(t, 1, len(builds))
+		root := builds[0]
+		assert.Equal(t, typeFor[Service1](), root.Type)
+		assert.Equal(t, 3, len(root.Calls))
+		assert.Equal(t, typeFor[Service2](), root.Calls[0].Type)
+		assert.Equal(t, typeFor[Service3](), root.Calls[1].Type)
+		assert.Equal(t, typeFor[[]int](), root.Calls[2].Type)
+
+		childTotal := root.Calls[0].Total + root.Calls[1].Total + root.Calls[2].Total
+		assert.Equal(t, root.Total-childTotal, root.Self)
+		assert.True(t, root.Total >= childTotal)
+
+		profile.Reset()
+		assert.Equal(t, 0, len(profile.Builds()))
+	})
+
+	t.Run("Failure recorded", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK}, WithObserver(profile))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+
+		builds := profile.Builds()
+		assert.Equal(t, 1, len(builds))
+		assert.ErrorIs(t, builds[0].Err, errTest1)
+		assert.Nil(t, builds[0].Calls[0].Err)
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK}, WithObserver(profile))
+		assert.Nil(t, err)
+		_, _ = Build[Service1](c)
+
+		buf := bytes.Buffer{}
+		assert.Nil(t, profile.WriteReport(&buf))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.Contains(t, lines[0], "SELF")
+		assert.Contains(t, buf.String(), "autowire.Service1 (github.com/tiendc/autowire.NewSrv1_Fail_With_Err_With_Need_Srv2")
+		assert.Contains(t, buf.String(), "autowire.Service2 (github.com/tiendc/autowire.NewSrv2_OK")
+		assert.Contains(t, buf.String(), "error: errTest1")
+	})
+
+	t.Run("Chrome trace", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_Fail_With_Err_With_Need_Srv2, NewSrv2_OK}, WithObserver(profile))
+		assert.Nil(t, err)
+		_, _ = Build[Service1](c)
+
+		buf := bytes.Buffer{}
+		assert.Nil(t, profile.WriteChromeTrace(&buf))
+		var trace struct {
+			TraceEvents []chromeTraceEvent `json:"traceEvents"`
+		}
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &trace))
+		assert.Equal(t, 2, len(trace.TraceEvents))
+		assert.Equal(t, "autowire.Service1", trace.TraceEvents[0].Name)
+		assert.Equal(t, "X", trace.TraceEvents[0].Ph)
+		assert.Contains(t, trace.TraceEvents[0].Args["error"], "errTest1")
+		assert.Equal(t, "autowire.Service2", trace.TraceEvents[1].Name)
+		assert.True(t, trace.TraceEvents[1].Ts >= trace.TraceEvents[0].Ts)
+	})
+
+	t.Run("Concurrent builds", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK}, WithObserver(profile))
+		assert.Nil(t, err)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = Build[Service1](c, NonSharedMode())
+				buf := bytes.Buffer{}
+				_ = profile.WriteReport(&buf)
+			}()
+		}
+		wg.Wait()
+
+		builds := profile.Builds()
+		assert.Equal(t, 10, len(builds))
+		for _, root := range builds {
+			assert.Equal(t, typeFor[Service1](), root.Type)
+			assert.Equal(t, 2, len(root.Calls))
+			assert.Equal(t, typeFor[Service2](), root.Calls[0].Type)
+			assert.Equal(t, typeFor[Service3](), root.Calls[1].Type)
+		}
+	})
+
+	t.Run("Builds returns a copy", func(t *testing.T) {
+		profile := NewProfile()
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK, NewSrv3_OK}, WithObserver(profile))
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+
+		builds := profile.Builds()
+		builds[0].Calls = nil
+		assert.Equal(t, 2, len(profile.Builds()[0].Calls))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		assert.Nil(t, NewProfile().WriteChromeTrace(&buf))
+		assert.Equal(t, "{\"traceEvents\":[]}\n", buf.String())
+	})
+}
@@ -1,11 +1,18 @@
 package autowire
 
 import (
+	"fmt"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
 )
 
 var (
 	typeError = typeFor[error]()
+
+	// pkgPath import path of this package, used to skip internal frames when finding call sites
+	pkgPath = typeFor[container]().PkgPath()
 )
 
 // Provider a provider is an `object creator` and provide the object to a container.
@@ -16,6 +23,9 @@ type Provider interface {
 	// Source returns provider source which can be a function, a struct pointer, or a value
 	Source() any
 
+	// Info returns metadata of the provider such as its name and source location
+	Info() ProviderInfo
+
 	// TargetTypes returns a list of target types that the provider can create objects of.
 	// A function provider can provide only one target type, whereas a struct provider can
 	// provide multiple target types through its fields.
@@ -30,13 +40,96 @@ type Provider interface {
 	Build(*Context, reflect.Type) (reflect.Value, error)
 }
 
+// ProviderKind kind of a provider
+type ProviderKind string
+
+const (
+	// ProviderKindFunc provider created from a function
+	ProviderKindFunc ProviderKind = "func"
+	// ProviderKindStruct provider created from a struct pointer
+	ProviderKindStruct ProviderKind = "struct"
+	// ProviderKindValue provider which holds a value, such as ones created by `ProviderOverwrite`
+	ProviderKindValue ProviderKind = "value"
+	// ProviderKindCustom provider implemented outside this package
+	ProviderKindCustom ProviderKind = "custom"
+)
+
+// ProviderInfo metadata of a provider used in diagnostics
+type ProviderInfo struct {
+	// Kind is the kind of the provider
+	Kind ProviderKind
+	// Name is the function name for a function provider, or the source type for other providers
+	Name string
+	// File and Line locate the definition of a function provider (empty for other providers)
+	File string
+	Line int
+	// CallerFile and CallerLine locate the call site which registered the provider
+	CallerFile string
+	CallerLine int
+}
+
+// String returns the provider name with its definition location if available
+func (info ProviderInfo) String() string {
+	if info.File == "" {
+		return info.Name
+	}
+	return fmt.Sprintf("%s (%s:%d)", info.Name, filepath.Base(info.File), info.Line)
+}
+
 // baseProvider base provider struct
 type baseProvider struct {
 	source    any
 	sourceVal reflect.Value
+	info      ProviderInfo
 }
 
 // Source returns the provider source
 func (p *baseProvider) Source() any {
 	return p.source
 }
+
+// Info returns the provider metadata
+func (p *baseProvider) Info() ProviderInfo {
+	return p.info
+}
+
+// callSite location of a call which registers providers
+type callSite struct {
+	file string
+	line int
+}
+
+// newProviderInfo creates provider metadata from the provider source.
+// For functions, the name and location are collected via `runtime.FuncForPC`.
+func newProviderInfo(provSrc any, provVal reflect.Value, caller callSite) ProviderInfo {
+	info := ProviderInfo{
+		Kind:       ProviderKindStruct,
+		Name:       fmt.Sprintf("%v", reflect.TypeOf(provSrc)),
+		CallerFile: caller.file,
+		CallerLine: caller.line,
+	}
+	if provVal.Kind() == reflect.Func && !provVal.IsNil() {
+		info.Kind = ProviderKindFunc
+		if fn := runtime.FuncForPC(provVal.Pointer()); fn != nil {
+			info.Name = fn.Name()
+			info.File, info.Line = fn.FileLine(fn.Entry())
+		}
+	}
+	return info
+}
+
+// callerLocation returns the location of the first caller outside this package
+func callerLocation() callSite {
+	pcs := make([]uintptr, 32)   //nolint:gomnd
+	n := runtime.Callers(2, pcs) //nolint:gomnd
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPath+".") || strings.HasSuffix(frame.File, "_test.go") {
+			return callSite{file: frame.File, line: frame.Line}
+		}
+		if !more {
+			return callSite{}
+		}
+	}
+}
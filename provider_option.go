@@ -0,0 +1,85 @@
+package autowire
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProviderOption configuration setter for a provider created by NewProvider
+type ProviderOption func(*providerOptions)
+
+// providerOptions options of a function provider
+type providerOptions struct {
+	deriveContext func(context.Context) (context.Context, func())
+	timeout       time.Duration
+	retries       int
+	backoff       func(attempt int) time.Duration
+}
+
+// DeriveContext sets a function to derive a child context from the context of the current build.
+// The child context is passed to the provider function and to all of its dependencies, which is
+// useful for adding values or starting a tracing span. The returned `finish` function is called
+// after the provider function returns. When the build has no context, context.Background() is
+// used as the parent context.
+func DeriveContext(fn func(ctx context.Context) (child context.Context, finish func())) ProviderOption {
+	return func(opts *providerOptions) {
+		opts.deriveContext = fn
+	}
+}
+
+// WithTimeout sets timeout for each call of the provider function. The context passed to the function
+// carries the deadline. When the function does not return before the deadline, the call is abandoned
+// and an error wrapping context.DeadlineExceeded is returned. When the build has no context,
+// context.Background() is used as the parent context.
+// An abandoned call keeps running in its goroutine until the function returns, the function should
+// stop its work when the context is done.
+func WithTimeout(timeout time.Duration) ProviderOption {
+	return func(opts *providerOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithRetry retries calling the provider function up to `retries` times when it returns an error
+// or times out. The `backoff` function returns the duration to wait before the next attempt
+// (attempt number starts from 1), it can be nil to retry immediately.
+// Errors returned by dependencies are not retried.
+// When combined with WithTimeout, a retry starts while the abandoned previous call may still be running,
+// so the calls can overlap.
+func WithRetry(retries int, backoff func(attempt int) time.Duration) ProviderOption {
+	return func(opts *providerOptions) {
+		opts.retries = retries
+		opts.backoff = backoff
+	}
+}
+
+// NewProvider creates a provider from a function with options.
+// The function must be in one of the forms accepted by NewContainer.
+func NewProvider(source any, opts ...ProviderOption) (Provider, error) {
+	provider, err := newProvider(source, callerLocation())
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) == 0 {
+		return provider, nil
+	}
+
+	funcProv, ok := provider.(*funcProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: options are only supported by function providers, got '%v'",
+			ErrProviderInvalid, provider.Info())
+	}
+	for _, opt := range opts {
+		opt(&funcProv.options)
+	}
+	return funcProv, nil
+}
+
+// MustNewProvider creates a provider with panicking on error
+func MustNewProvider(source any, opts ...ProviderOption) Provider {
+	provider, err := NewProvider(source, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return provider
+}
@@ -0,0 +1,205 @@
+package autowire
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("Options are not supported by struct providers", func(t *testing.T) {
+		_, err := NewProvider(&struct1_OK, DeriveContext(func(ctx context.Context) (context.Context, func()) {
+			return ctx, nil
+		}))
+		assert.ErrorIs(t, err, ErrProviderInvalid)
+		assert.Contains(t, err.Error(),
+			"ErrProviderInvalid: options are only supported by function providers, got '*autowire.Struct1_OK'")
+	})
+
+	t.Run("Invalid provider, MustNewProvider panics", func(t *testing.T) {
+		defer func() {
+			err := recover().(error)
+			assert.ErrorIs(t, err, ErrProviderInvalid)
+		}()
+		_ = MustNewProvider(NewSrv1_Fail_With_Variadic)
+	})
+
+	t.Run("Provider without options", func(t *testing.T) {
+		p := MustNewProvider(NewSrv1_OK)
+		assert.Equal(t, typeFor[Service1](), p.TargetTypes()[0])
+		assert.Equal(t, ProviderKindFunc, p.Info().Kind)
+	})
+}
+
+func TestDeriveContext(t *testing.T) {
+	newSrv2 := func(ctx context.Context) Service2 {
+		return &service2{serviceBase{initArgs: []any{ctx.Value(ctxKey{})}}}
+	}
+	newSrv3 := func(ctx context.Context) Service3 {
+		return &service3{serviceBase{initArgs: []any{ctx.Value(ctxKey{})}}}
+	}
+	newSrv1 := func(ctx context.Context, s2 Service2) Service1 {
+		return &service1{serviceBase{initArgs: []any{ctx.Value(ctxKey{}), s2}}}
+	}
+
+	t.Run("Child context flows to dependencies only", func(t *testing.T) {
+		finished := 0
+		p2 := MustNewProvider(newSrv2, DeriveContext(func(ctx context.Context) (context.Context, func()) {
+			return context.WithValue(ctx, ctxKey{}, "child"), func() { finished++ }
+		}))
+		// S1 -> S2 -> ctx
+		c, err := NewContainer([]any{newSrv1, p2})
+		assert.Nil(t, err)
+		s1, err := BuildWithCtx[Service1](context.WithValue(context.Background(), ctxKey{}, "root"), c)
+		assert.Nil(t, err)
+		assert.Equal(t, "root", s1.InitArgs()[0])
+		assert.Equal(t, []any{"child"}, s1.InitArgs()[1].(Service2).InitArgs())
+		assert.Equal(t, 1, finished)
+	})
+
+	t.Run("Child context of a provider is passed to its dependencies", func(t *testing.T) {
+		newSrv1 := func(s3 Service3) Service1 {
+			return &service1{serviceBase{initArgs: []any{s3}}}
+		}
+		p1 := MustNewProvider(newSrv1, DeriveContext(func(ctx context.Context) (context.Context, func()) {
+			return context.WithValue(ctx, ctxKey{}, "child"), nil
+		}))
+		c, err := NewContainer([]any{p1, newSrv3})
+		assert.Nil(t, err)
+		// No context passed to the build, context.Background() is used as parent
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"child"}, s1.InitArgs()[0].(Service3).InitArgs())
+	})
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("Provider times out", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		p1 := MustNewProvider(func() Service1 {
+			<-release
+			return &service1{}
+		}, WithTimeout(10*time.Millisecond))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "context deadline exceeded: provider did not return within 10ms: provider '")
+		assert.Contains(t, err.Error(), "failed to build type 'autowire.Service1'")
+	})
+
+	t.Run("Context passed to provider carries the deadline", func(t *testing.T) {
+		var deadline time.Time
+		p1 := MustNewProvider(func(ctx context.Context, s2 Service2) Service1 {
+			deadline, _ = ctx.Deadline()
+			return &service1{serviceBase{initArgs: []any{s2}}}
+		}, WithTimeout(time.Minute))
+		c, err := NewContainer([]any{p1, NewSrv2_OK_With_Need_Ctx})
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+		// Dependencies get the context without the deadline
+		_, hasDeadline := s1.InitArgs()[0].(Service2).InitArgs()[0].(context.Context).Deadline()
+		assert.False(t, hasDeadline)
+	})
+
+	t.Run("Argument of an interface embedding context.Context is passed unchanged", func(t *testing.T) {
+		type customCtx interface {
+			context.Context
+			Method()
+		}
+		provided := customCtxImpl{Context: context.Background()}
+		p1 := MustNewProvider(func(ctx customCtx) Service1 {
+			return &service1{serviceBase{initArgs: []any{ctx}}}
+		}, WithTimeout(time.Minute))
+		c, err := NewContainer([]any{p1, func() customCtx { return provided }})
+		assert.Nil(t, err)
+		s1, err := BuildWithCtx[Service1](context.Background(), c)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{provided}, s1.InitArgs())
+	})
+
+	t.Run("Panic is propagated", func(t *testing.T) {
+		p1 := MustNewProvider(func() Service1 { panic("s1 panics") }, WithTimeout(time.Minute))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		assert.PanicsWithValue(t, "s1 panics", func() {
+			_, _ = Build[Service1](c)
+		})
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Run("Success after retries", func(t *testing.T) {
+		calls := 0
+		var waits []int
+		p1 := MustNewProvider(func() (Service1, error) {
+			calls++
+			if calls < 3 {
+				return nil, errTest1
+			}
+			return &service1{}, nil
+		}, WithRetry(3, func(attempt int) time.Duration {
+			waits = append(waits, attempt)
+			return time.Millisecond
+		}))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []int{1, 2}, waits)
+	})
+
+	t.Run("Failure annotated with attempt count", func(t *testing.T) {
+		calls := 0
+		p1 := MustNewProvider(func() (Service1, error) {
+			calls++
+			return nil, errTest1
+		}, WithRetry(2, nil))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.ErrorIs(t, err, errTest1)
+		assert.Contains(t, err.Error(), "failed to build type 'autowire.Service1' after 3 attempts")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Timed out attempts are retried", func(t *testing.T) {
+		calls := int32(0)
+		p1 := MustNewProvider(func(ctx context.Context) Service1 {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+			}
+			return &service1{}
+		}, WithTimeout(10*time.Millisecond), WithRetry(1, nil))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Retry stops when the build is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		p1 := MustNewProvider(func() (Service1, error) {
+			calls++
+			cancel()
+			return nil, errTest1
+		}, WithRetry(5, func(int) time.Duration { return time.Minute }))
+		c, err := NewContainer([]any{p1})
+		assert.Nil(t, err)
+		_, err = BuildWithCtx[Service1](ctx, c)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -3,6 +3,8 @@ package autowire
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 )
 
 // ProviderSet is a set of unique providers for certain unique types
@@ -13,23 +15,88 @@ type ProviderSet interface {
 	// GetAll returns all providers contained within the set
 	GetAll() []Provider
 
-	// Overwrite replaces the existing provider by its target type with the specified one
+	// Overwrite replaces the existing providers of the target types with the specified one.
+	// Sets cloned from this set before are not affected.
 	Overwrite(Provider)
 
-	// shallowClone clones the set (shallow clone only)
+	// shallowClone clones the set (shallow clone only). Overwriting the clone doesn't affect
+	// this set and vice versa.
 	shallowClone() ProviderSet
+
+	// extend creates a new set with the base providers of this set and the specified providers.
+	// Overwritten providers of this set are kept in the new set.
+	extend(providers []Provider) (ProviderSet, error)
+
+	// replace creates a new set with the base providers of this set, in which the providers of
+	// the target types of the specified provider are replaced by it.
+	// Overwritten providers of this set are kept in the new set, except the ones of the replaced types.
+	replace(provider Provider) (ProviderSet, error)
+
+	// remove creates a new set with the base providers of this set except the provider of the type.
+	// Overwritten providers of this set are kept in the new set, except the one of the removed type.
+	remove(targetType reflect.Type) (ProviderSet, error)
 }
 
 // providerSet default implementation of ProviderSet interface
 type providerSet struct {
-	providerMap            map[reflect.Type]Provider
-	overwrittenProviderMap map[reflect.Type]Provider
+	// providerMap base providers of the set, it is never modified after the set is created
+	providerMap map[reflect.Type]Provider
+	// overlay the top layer of overwritten providers, nil when nothing is overwritten
+	overlay *providerOverlay
+	// mu guards overlay
+	mu sync.RWMutex
+}
+
+// providerOverlay an immutable layer of overwritten providers on top of its parent layer.
+// Layers are shared between cloned sets, so they must never be modified after creation.
+type providerOverlay struct {
+	parent      *providerOverlay
+	providerMap map[reflect.Type]Provider
+}
+
+func (ps *providerSet) topOverlay() *providerOverlay {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	return ps.overlay
+}
+
+// overlayWithout returns an overlay of the overwritten providers of this set except the ones of the types
+func (ps *providerSet) overlayWithout(types []reflect.Type) *providerOverlay {
+	top := ps.topOverlay()
+	excluded := make(map[reflect.Type]struct{}, len(types))
+	overwritten := false
+	for _, typ := range types {
+		excluded[typ] = struct{}{}
+		for layer := top; layer != nil && !overwritten; layer = layer.parent {
+			_, overwritten = layer.providerMap[typ]
+		}
+	}
+	if !overwritten {
+		// Layers are immutable, so they can be shared
+		return top
+	}
+
+	providerMap := map[reflect.Type]Provider{}
+	for layer := top; layer != nil; layer = layer.parent {
+		for typ, prov := range layer.providerMap {
+			if _, exist := excluded[typ]; exist {
+				continue
+			}
+			if _, exist := providerMap[typ]; !exist {
+				providerMap[typ] = prov
+			}
+		}
+	}
+	if len(providerMap) == 0 {
+		return nil
+	}
+	return &providerOverlay{providerMap: providerMap}
 }
 
 // GetFor implementation of ProviderSet interface
 func (ps *providerSet) GetFor(targetType reflect.Type) (Provider, error) {
-	if len(ps.overwrittenProviderMap) > 0 {
-		if prov, exist := ps.overwrittenProviderMap[targetType]; exist {
+	for layer := ps.topOverlay(); layer != nil; layer = layer.parent {
+		if prov, exist := layer.providerMap[targetType]; exist {
 			return prov, nil
 		}
 	}
@@ -41,17 +108,21 @@ func (ps *providerSet) GetFor(targetType reflect.Type) (Provider, error) {
 
 // GetAll implementation of ProviderSet interface
 func (ps *providerSet) GetAll() []Provider {
-	numOverwritten := len(ps.overwrittenProviderMap)
-	ret := make([]Provider, 0, len(ps.providerMap)+numOverwritten)
-	for typ, v := range ps.providerMap {
-		if numOverwritten > 0 {
-			if _, exist := ps.overwrittenProviderMap[typ]; exist {
+	ret := make([]Provider, 0, len(ps.providerMap))
+	overwrittenTypes := map[reflect.Type]struct{}{}
+	for layer := ps.topOverlay(); layer != nil; layer = layer.parent {
+		for typ, v := range layer.providerMap {
+			if _, exist := overwrittenTypes[typ]; exist {
 				continue
 			}
+			overwrittenTypes[typ] = struct{}{}
+			ret = append(ret, v)
 		}
-		ret = append(ret, v)
 	}
-	for _, v := range ps.overwrittenProviderMap {
+	for typ, v := range ps.providerMap {
+		if _, exist := overwrittenTypes[typ]; exist {
+			continue
+		}
 		ret = append(ret, v)
 	}
 	return ret
@@ -59,18 +130,83 @@ func (ps *providerSet) GetAll() []Provider {
 
 // Overwrite implementation of ProviderSet interface
 func (ps *providerSet) Overwrite(provider Provider) {
-	if ps.overwrittenProviderMap == nil {
-		ps.overwrittenProviderMap = map[reflect.Type]Provider{}
+	targetTypes := provider.TargetTypes()
+	layer := &providerOverlay{
+		providerMap: make(map[reflect.Type]Provider, len(targetTypes)),
+	}
+	for _, targetType := range targetTypes {
+		layer.providerMap[targetType] = provider
 	}
-	ps.overwrittenProviderMap[provider.TargetTypes()[0]] = provider
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	layer.parent = ps.overlay
+	ps.overlay = layer
 }
 
 // shallowClone implementation of ProviderSet interface
 func (ps *providerSet) shallowClone() ProviderSet {
 	return &providerSet{
-		providerMap:            ps.providerMap,
-		overwrittenProviderMap: ps.overwrittenProviderMap,
+		providerMap: ps.providerMap,
+		overlay:     ps.topOverlay(),
+	}
+}
+
+// extend implementation of ProviderSet interface
+func (ps *providerSet) extend(providers []Provider) (ProviderSet, error) {
+	providerMap := make(map[reflect.Type]Provider, len(ps.providerMap)+len(providers))
+	for typ, provider := range ps.providerMap {
+		providerMap[typ] = provider
+	}
+	for _, provider := range uniqueProviders(providers) {
+		if err := addProviderToMap(provider, providerMap); err != nil {
+			return nil, err
+		}
+	}
+	return &providerSet{
+		providerMap: providerMap,
+		overlay:     ps.topOverlay(),
+	}, nil
+}
+
+// replace implementation of ProviderSet interface
+func (ps *providerSet) replace(provider Provider) (ProviderSet, error) {
+	providerMap := make(map[reflect.Type]Provider, len(ps.providerMap))
+	for typ, prov := range ps.providerMap {
+		providerMap[typ] = prov
+	}
+	targetTypes := provider.TargetTypes()
+	for _, targetType := range targetTypes {
+		if isContextType(targetType) {
+			return nil, fmt.Errorf("%w: type '%v' must not be provided, it is passed via BuildWithCtx, "+
+				"error at '%v'", ErrProviderInvalid, targetType, provider.Info())
+		}
+		if _, err := ps.GetFor(targetType); err != nil {
+			return nil, fmt.Errorf("%w: provider not found for type '%v' to replace", ErrNotFound, targetType)
+		}
+		providerMap[targetType] = provider
+	}
+	return &providerSet{
+		providerMap: providerMap,
+		overlay:     ps.overlayWithout(targetTypes),
+	}, nil
+}
+
+// remove implementation of ProviderSet interface
+func (ps *providerSet) remove(targetType reflect.Type) (ProviderSet, error) {
+	if _, err := ps.GetFor(targetType); err != nil {
+		return nil, fmt.Errorf("%w: provider not found for type '%v' to remove", ErrNotFound, targetType)
+	}
+	providerMap := make(map[reflect.Type]Provider, len(ps.providerMap))
+	for typ, prov := range ps.providerMap {
+		if typ != targetType {
+			providerMap[typ] = prov
+		}
 	}
+	return &providerSet{
+		providerMap: providerMap,
+		overlay:     ps.overlayWithout([]reflect.Type{targetType}),
+	}, nil
 }
 
 // NewProviderSet creates a new provider set from individual providers.
@@ -95,8 +231,12 @@ func MustNewProviderSet(args ...any) ProviderSet {
 	return ps
 }
 
-//nolint:gocognit
 func parseProviders(args ...any) (ProviderSet, error) {
+	return parseProvidersAt(callerLocation(), args...)
+}
+
+// parseProvidersAt parses providers created at the specified caller location
+func parseProvidersAt(caller callSite, args ...any) (ProviderSet, error) {
 	providerMap := make(map[reflect.Type]Provider, len(args))
 	var err error
 
@@ -120,29 +260,7 @@ func parseProviders(args ...any) (ProviderSet, error) {
 			continue
 		}
 
-		provVal := reflect.ValueOf(provSrc)
-		kind := provVal.Kind()
-		if kind == reflect.Interface || kind == reflect.Pointer {
-			provVal = provVal.Elem()
-			if !provVal.IsValid() {
-				return nil, fmt.Errorf("%w: provider must not be nil", ErrProviderInvalid)
-			}
-		}
-
-		var provider Provider
-		switch provVal.Kind() { //nolint:exhaustive
-		case reflect.Func:
-			provider, err = newFuncProvider(provSrc, provVal)
-		case reflect.Struct:
-			if kind != reflect.Pointer {
-				return nil, fmt.Errorf("%w: struct pointer required, got '%v'",
-					ErrProviderInvalid, reflect.TypeOf(provSrc))
-			}
-			provider, err = newStructProvider(provSrc, provVal)
-		default:
-			return nil, fmt.Errorf("%w: provider type unsupported, got '%v'",
-				ErrProviderInvalid, reflect.TypeOf(provSrc))
-		}
+		provider, err := newProvider(provSrc, caller)
 		if err != nil {
 			return nil, err
 		}
@@ -159,11 +277,94 @@ func parseProviders(args ...any) (ProviderSet, error) {
 	}, nil
 }
 
+// newProvider creates a provider from a function or a struct pointer
+func newProvider(provSrc any, caller callSite) (Provider, error) {
+	provVal := reflect.ValueOf(provSrc)
+	kind := provVal.Kind()
+	if kind == reflect.Interface || kind == reflect.Pointer {
+		provVal = provVal.Elem()
+		if !provVal.IsValid() {
+			return nil, fmt.Errorf("%w: provider must not be nil", ErrProviderInvalid)
+		}
+	}
+
+	var provider Provider
+	var err error
+	switch provVal.Kind() { //nolint:exhaustive
+	case reflect.Func:
+		provider, err = newFuncProvider(provSrc, provVal, caller)
+	case reflect.Struct:
+		if kind != reflect.Pointer {
+			return nil, fmt.Errorf("%w: struct pointer required, got '%v'",
+				ErrProviderInvalid, reflect.TypeOf(provSrc))
+		}
+		provider, err = newStructProvider(provSrc, provVal, caller)
+	default:
+		return nil, fmt.Errorf("%w: provider type unsupported, got '%v'",
+			ErrProviderInvalid, reflect.TypeOf(provSrc))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// uniqueProviders removes duplicates from the providers and sorts them by name.
+// A provider of multiple types appears multiple times in the result of ProviderSet.GetAll.
+func uniqueProviders(providers []Provider) []Provider {
+	ret := make([]Provider, 0, len(providers))
+	seen := make(map[providerIdentity]struct{}, len(providers))
+	for _, provider := range providers {
+		if id, ok := identityOf(provider); ok {
+			if _, exist := seen[id]; exist {
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+		ret = append(ret, provider)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Info().String() < ret[j].Info().String()
+	})
+	return ret
+}
+
+// providerIdentity identity of a provider implemented by a pointer type
+type providerIdentity struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// identityOf returns the identity of a provider implemented by a pointer type. Providers of other kinds
+// have no identity, as comparing them can panic when their types are not comparable.
+func identityOf(provider Provider) (providerIdentity, bool) {
+	val := reflect.ValueOf(provider)
+	if val.Kind() != reflect.Pointer {
+		return providerIdentity{}, false
+	}
+	return providerIdentity{typ: val.Type(), ptr: val.Pointer()}, true
+}
+
+// sameProvider checks if the providers are the same without comparing values of non-comparable types
+func sameProvider(p1, p2 Provider) bool {
+	id1, ok1 := identityOf(p1)
+	id2, ok2 := identityOf(p2)
+	if ok1 || ok2 {
+		return ok1 && ok2 && id1 == id2
+	}
+	typ := reflect.TypeOf(p1)
+	return typ != nil && typ == reflect.TypeOf(p2) && typ.Comparable() && p1 == p2
+}
+
 func addProviderToMap(provider Provider, providerMap map[reflect.Type]Provider) error {
 	for _, targetType := range provider.TargetTypes() {
-		if _, exist := providerMap[targetType]; exist {
-			return fmt.Errorf("%w: duplicated provider for type '%v'",
-				ErrProviderDuplicated, targetType)
+		if isContextType(targetType) {
+			return fmt.Errorf("%w: type '%v' must not be provided, it is passed via BuildWithCtx, "+
+				"error at '%v'", ErrProviderInvalid, targetType, provider.Info())
+		}
+		if existing, exist := providerMap[targetType]; exist {
+			return fmt.Errorf("%w: duplicated provider for type '%v', provided by '%v' and '%v'",
+				ErrProviderDuplicated, targetType, existing.Info(), provider.Info())
 		}
 		providerMap[targetType] = provider
 	}
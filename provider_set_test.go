@@ -90,10 +90,71 @@ func TestNewProviderSet_Success(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, 1, len(ps1.GetAll()))
 		// Overwrite an existing type
-		ps1.Overwrite(newValueProvider(NewSrv1_OK(), reflect.ValueOf(NewSrv1_OK())))
+		ps1.Overwrite(newValueProvider(NewSrv1_OK(), reflect.ValueOf(NewSrv1_OK()), callSite{}))
 		assert.Equal(t, 1, len(ps1.GetAll()))
 		// Overwrite a non-existing type
-		ps1.Overwrite(newValueProvider(123, reflect.ValueOf(123)))
+		ps1.Overwrite(newValueProvider(123, reflect.ValueOf(123), callSite{}))
 		assert.Equal(t, 2, len(ps1.GetAll()))
 	})
+
+	t.Run("Overwrite doesn't affect clones", func(t *testing.T) {
+		ps1, err := NewProviderSet(NewSrv1_OK, NewSrv2_OK)
+		assert.Nil(t, err)
+		ps2 := ps1.shallowClone()
+		fakeS1 := newValueProvider[Service1](&service1{}, reflect.ValueOf(&service1{}), callSite{})
+		ps2.Overwrite(fakeS1)
+		p, err := ps2.GetFor(typeFor[Service1]())
+		assert.Nil(t, err)
+		assert.Same(t, fakeS1, p)
+		p, err = ps1.GetFor(typeFor[Service1]())
+		assert.Nil(t, err)
+		assert.Equal(t, ProviderKindFunc, p.Info().Kind)
+
+		// Overwriting the original set doesn't affect existing clones
+		fakeS2 := newValueProvider[Service2](&service2{}, reflect.ValueOf(&service2{}), callSite{})
+		ps1.Overwrite(fakeS2)
+		p, err = ps2.GetFor(typeFor[Service2]())
+		assert.Nil(t, err)
+		assert.Equal(t, ProviderKindFunc, p.Info().Kind)
+		ps3 := ps1.shallowClone()
+		p, err = ps3.GetFor(typeFor[Service2]())
+		assert.Nil(t, err)
+		assert.Same(t, fakeS2, p)
+
+		// Latest overwrite wins
+		fakeS1b := newValueProvider[Service1](&service1{}, reflect.ValueOf(&service1{}), callSite{})
+		ps2.Overwrite(fakeS1b)
+		p, err = ps2.GetFor(typeFor[Service1]())
+		assert.Nil(t, err)
+		assert.Same(t, fakeS1b, p)
+		assert.Equal(t, 2, len(ps2.GetAll()))
+	})
+}
+
+// sliceProvider a custom provider of a non-comparable type
+type sliceProvider struct {
+	Provider
+	types []reflect.Type
+}
+
+func (p sliceProvider) Info() ProviderInfo {
+	return ProviderInfo{Kind: ProviderKindCustom, Name: p.types[0].String()}
+}
+
+func Test_uniqueProviders(t *testing.T) {
+	t.Run("Non-comparable providers", func(t *testing.T) {
+		p1 := sliceProvider{types: []reflect.Type{typeFor[Service1]()}}
+		p2 := sliceProvider{types: []reflect.Type{typeFor[Service2]()}}
+		assert.NotPanics(t, func() {
+			assert.Equal(t, 3, len(uniqueProviders([]Provider{p2, p1, p1})))
+		})
+	})
+
+	t.Run("Pointer providers", func(t *testing.T) {
+		ps, err := NewProviderSet(NewSrv1_OK, &struct5_OK)
+		assert.Nil(t, err)
+		providers := uniqueProviders(ps.GetAll())
+		assert.Equal(t, 2, len(providers))
+		assert.Equal(t, "*autowire.Struct5_OK", providers[0].Info().Name)
+	})
 }
@@ -79,7 +79,12 @@ func (p *structProvider) parseStructRecursively(rootType, typ reflect.Type, inde
 }
 
 // Build returns related field value for the specified type
-func (p *structProvider) Build(ctx *Context, targetType reflect.Type) (reflect.Value, error) {
+func (p *structProvider) Build(ctx *Context, targetType reflect.Type) (value reflect.Value, err error) {
+	observeBuildEnd := ctx.observeBuildStart(targetType, p)
+	defer func() {
+		observeBuildEnd(err)
+	}()
+
 	if fieldDetail, exist := p.targetTypes[targetType]; exist {
 		val, err := p.sourceVal.FieldByIndexErr(fieldDetail.Index)
 		if err != nil {
@@ -91,9 +96,13 @@ func (p *structProvider) Build(ctx *Context, targetType reflect.Type) (reflect.V
 }
 
 // newStructProvider creates a struct provider
-func newStructProvider(provSrc any, provVal reflect.Value) (*structProvider, error) {
+func newStructProvider(provSrc any, provVal reflect.Value, caller callSite) (*structProvider, error) {
 	provider := &structProvider{
-		baseProvider: baseProvider{source: provSrc, sourceVal: provVal},
+		baseProvider: baseProvider{
+			source:    provSrc,
+			sourceVal: provVal,
+			info:      newProviderInfo(provSrc, provVal, caller),
+		},
 	}
 	if err := provider.parse(); err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package autowire
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -63,5 +64,8 @@ func TestStructProvider_Success(t *testing.T) {
 		prov2, err := ps1.GetFor(typeFor[*Nested2]())
 		assert.Nil(t, err)
 		assert.Equal(t, prov2.Source(), &struct7_OK_Nested)
+		assert.Equal(t, "*autowire.Struct7_OK_Nested", prov2.Info().Name)
+		assert.Equal(t, "", prov2.Info().File)
+		assert.True(t, strings.HasSuffix(prov2.Info().CallerFile, "struct_provider_test.go"))
 	})
 }
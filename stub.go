@@ -0,0 +1,81 @@
+package autowire
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// StubFactory creates a stub object for an interface type which has no provider.
+// It returns false when it doesn't handle the type.
+type StubFactory func(typ reflect.Type) (any, bool)
+
+// StubMissingInterfaces test mode option for the current build. When a provider depends on an
+// interface type which has no provider, the stub factories are asked in order to create an object
+// for the type. If none of them handles the type, the build fails with ErrNotFound.
+// Stub objects and objects depending on them are never cached, even in shared mode.
+//
+// Note that Go can't create implementations of interfaces at runtime. Use a stub factory to provide
+// fakes or mocks for the dependencies which are actually used, and ZeroStub for the others.
+func StubMissingInterfaces(factories ...StubFactory) ContextOption {
+	return func(ctx *Context) {
+		ctx.stubMissing = true
+		ctx.stubFactories = append(ctx.stubFactories, factories...)
+	}
+}
+
+// Stub creates a stub factory which returns the object for type T
+func Stub[T any](val T) StubFactory {
+	targetType := typeFor[T]()
+	return func(typ reflect.Type) (any, bool) {
+		if typ != targetType {
+			return nil, false
+		}
+		return val, true
+	}
+}
+
+// ZeroStub creates a stub factory which handles every type with its zero value (a nil interface).
+// Calling a method of the stub panics with a nil pointer dereference. Put it last as a fallback
+// for the dependencies which are not used.
+func ZeroStub() StubFactory {
+	return func(reflect.Type) (any, bool) {
+		return nil, true
+	}
+}
+
+// buildStub creates a stub object for an interface type which has no provider.
+// This returns the error of the provider lookup when the type can't be stubbed.
+func (ctx *Context) buildStub(typ reflect.Type, lookupErr error) (reflect.Value, error) {
+	if !ctx.stubMissing || typ.Kind() != reflect.Interface || !errors.Is(lookupErr, ErrNotFound) {
+		return reflect.Value{}, lookupErr
+	}
+	for _, factory := range ctx.stubFactories {
+		obj, ok := factory(typ)
+		if !ok {
+			continue
+		}
+		if obj == nil {
+			return reflect.Zero(typ), nil
+		}
+		value := reflect.ValueOf(obj)
+		if !value.Type().AssignableTo(typ) {
+			return reflect.Value{}, fmt.Errorf("%w: stub of type '%v' is not assignable to type '%v'",
+				ErrTypeCast, value.Type(), typ)
+		}
+		result := reflect.New(typ).Elem()
+		result.Set(value)
+		return result, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w (no stub factory handles the type, add one with Stub, "+
+		"or ZeroStub for unused dependencies)", lookupErr)
+}
+
+// isStubbable checks if objects of the type are created by stub factories in the current build
+func (ctx *Context) isStubbable(typ reflect.Type) bool {
+	if !ctx.stubMissing || typ.Kind() != reflect.Interface {
+		return false
+	}
+	_, err := ctx.providerSet.GetFor(typ)
+	return errors.Is(err, ErrNotFound)
+}
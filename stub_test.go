@@ -0,0 +1,98 @@
+package autowire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStubMissingInterfaces(t *testing.T) {
+	t.Run("Zero value", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv2_OK_With_Need_Srv4_Srv5})
+		assert.Nil(t, err)
+		s2, err := Build[Service2](c, StubMissingInterfaces(ZeroStub()))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{nil, nil}, s2.(*service2).initArgs)
+
+		// Stubs and objects depending on them are not cached
+		_, err = Get[Service4](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = Get[Service2](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Objects depending on stubs are not taken from the cache", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5, NewSrv3_OK,
+			NewSrv4_OK, NewSrv5_OK})
+		assert.Nil(t, err)
+		s1, err := Build[Service1](c)
+		assert.Nil(t, err)
+
+		c2, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3, NewSrv2_OK_With_Need_Srv4_Srv5, NewSrv3_OK})
+		assert.Nil(t, err)
+		_, err = Build[Service3](c2)
+		assert.Nil(t, err)
+		s4 := &service4{}
+		s1b, err := Build[Service1](c2, StubMissingInterfaces(Stub[Service4](s4), ZeroStub()))
+		assert.Nil(t, err)
+		assert.NotSame(t, s1, s1b)
+		assert.Same(t, s4, s1b.InitArgs()[0].(Service2).InitArgs()[0])
+
+		// Objects not depending on stubs are cached as usual
+		objects := c2.Objects()
+		assert.Equal(t, 1, len(objects))
+		assert.Equal(t, typeFor[Service3](), objects[0].Type)
+	})
+
+	t.Run("Stub factory", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv2_OK_With_Need_Srv4_Srv5})
+		assert.Nil(t, err)
+		s4 := &service4{}
+		s5 := &service5{}
+		s2, err := Build[Service2](c, NonSharedMode(), StubMissingInterfaces(Stub[Service4](s4),
+			func(typ reflect.Type) (any, bool) {
+				if typ == typeFor[Service5]() {
+					return s5, true
+				}
+				return nil, false
+			}))
+		assert.Nil(t, err)
+		assert.Same(t, s4, s2.(*service2).initArgs[0])
+		assert.Same(t, s5, s2.(*service2).initArgs[1])
+	})
+
+	t.Run("Failure: stub not assignable", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv2_OK_With_Need_Srv4_Srv5})
+		assert.Nil(t, err)
+		_, err = Build[Service2](c, StubMissingInterfaces(func(reflect.Type) (any, bool) {
+			return 123, true
+		}))
+		assert.ErrorIs(t, err, ErrTypeCast)
+		assert.Contains(t, err.Error(), "required by provider")
+	})
+
+	t.Run("Failure: non-interface type is not stubbed", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv1_OK_With_Need_Srv2_Srv3_IntSlice, NewSrv2_OK})
+		assert.Nil(t, err)
+		_, err = Build[Service1](c, StubMissingInterfaces(ZeroStub()))
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "'[]int'")
+	})
+
+	t.Run("Failure: no factory handles the type", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv2_OK_With_Need_Srv4_Srv5})
+		assert.Nil(t, err)
+		_, err = Build[Service2](c, StubMissingInterfaces(Stub[Service4](&service4{})))
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "provider not found for type 'autowire.Service5' (no stub factory "+
+			"handles the type, add one with Stub, or ZeroStub for unused dependencies)")
+	})
+
+	t.Run("Failure: without option", func(t *testing.T) {
+		c, err := NewContainer([]any{NewSrv2_OK_With_Need_Srv4_Srv5})
+		assert.Nil(t, err)
+		_, err = Build[Service2](c)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
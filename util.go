@@ -1,8 +1,21 @@
 package autowire
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
+
+var (
+	typeContext = typeFor[context.Context]()
+)
 
 // typeFor returns the [Type] that represents the type argument T.
 func typeFor[T any]() reflect.Type {
 	return reflect.TypeOf((*T)(nil)).Elem()
 }
+
+// isContextType checks if the type is context.Context. Interfaces embedding context.Context are
+// regular types, they can be provided.
+func isContextType(typ reflect.Type) bool {
+	return typ == typeContext
+}
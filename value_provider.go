@@ -26,9 +26,19 @@ func (p *valueProvider) Build(ctx *Context, targetType reflect.Type) (reflect.Va
 }
 
 // newValueProvider creates a value provider
-func newValueProvider[T any](provSrc T, provVal reflect.Value) *valueProvider {
+func newValueProvider[T any](provSrc T, provVal reflect.Value, caller callSite) *valueProvider {
+	targetType := typeFor[T]()
 	return &valueProvider{
-		baseProvider: baseProvider{source: provSrc, sourceVal: provVal},
-		targetType:   typeFor[T](),
+		baseProvider: baseProvider{
+			source:    provSrc,
+			sourceVal: provVal,
+			info: ProviderInfo{
+				Kind:       ProviderKindValue,
+				Name:       targetType.String(),
+				CallerFile: caller.file,
+				CallerLine: caller.line,
+			},
+		},
+		targetType: targetType,
 	}
 }
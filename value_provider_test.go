@@ -9,7 +9,7 @@ import (
 
 func TestValueProvider(t *testing.T) {
 	t.Run("Success with primitive type", func(t *testing.T) {
-		p := newValueProvider(123, reflect.ValueOf(123))
+		p := newValueProvider(123, reflect.ValueOf(123), callSite{})
 		assert.Equal(t, 1, len(p.TargetTypes()))
 		assert.Equal(t, typeFor[int](), p.TargetTypes()[0])
 		assert.Equal(t, 0, len(p.DependentTypes()))
@@ -17,7 +17,7 @@ func TestValueProvider(t *testing.T) {
 
 	t.Run("Success with pointer type", func(t *testing.T) {
 		v := "abc"
-		p := newValueProvider(&v, reflect.ValueOf(&v))
+		p := newValueProvider(&v, reflect.ValueOf(&v), callSite{})
 		assert.Equal(t, 1, len(p.TargetTypes()))
 		assert.Equal(t, typeFor[*string](), p.TargetTypes()[0])
 		assert.Equal(t, 0, len(p.DependentTypes()))